@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"anime-reminder/database"
+	"anime-reminder/models"
+	"anime-reminder/store"
+	"errors"
+	"time"
+)
+
+// AiringStateController mencatat status reminder per tayangan (fired, acknowledged)
+// supaya reminder tidak dikirim dua kali setelah restart
+type AiringStateController struct {
+	Store store.AiringStateStore
+}
+
+// NewAiringStateController membuat controller dengan store yang di-inject
+func NewAiringStateController(s store.AiringStateStore) *AiringStateController {
+	return &AiringStateController{Store: s}
+}
+
+func (ac *AiringStateController) store() store.AiringStateStore {
+	if ac.Store == nil {
+		return store.NewGormStore(database.GetDB())
+	}
+	return ac.Store
+}
+
+// update mengambil (atau membuat) status satu tayangan lalu menyimpannya setelah fn
+func (ac *AiringStateController) update(animeID, slotID uint, airDate string, offsetMinutes int, fn func(*models.AiringState)) error {
+	if airDate == "" {
+		return errors.New("airing date is required")
+	}
+
+	state, err := ac.store().GetAiringState(animeID, slotID, airDate, offsetMinutes)
+	if errors.Is(err, store.ErrNotFound) {
+		state = &models.AiringState{AnimeId: animeID, SlotId: slotID, AirDate: airDate, OffsetMinutes: offsetMinutes}
+	} else if err != nil {
+		return err
+	}
+
+	fn(state)
+	return ac.store().SaveAiringState(state)
+}
+
+// MarkFired mencatat bahwa reminder untuk tayangan ini sudah dikirim
+func (ac *AiringStateController) MarkFired(animeID, slotID uint, airDate string, offsetMinutes int, at time.Time) error {
+	return ac.update(animeID, slotID, airDate, offsetMinutes, func(state *models.AiringState) {
+		if state.FiredAt == nil {
+			state.FiredAt = &at
+		}
+	})
+}
+
+// MarkAcknowledged mencatat bahwa reminder event sudah di-dismiss user
+func (ac *AiringStateController) MarkAcknowledged(event models.ReminderEvent, at time.Time) error {
+	return ac.update(event.AnimeId, event.SlotId, event.AirDate, event.OffsetMinutes, func(state *models.AiringState) {
+		state.AcknowledgedAt = &at
+	})
+}
+
+// Since mengembalikan status semua tayangan sejak tanggal since
+func (ac *AiringStateController) Since(since time.Time) ([]models.AiringState, error) {
+	return ac.store().ListAiringStates(since.Format("2006-01-02"))
+}
+
+// Prune menghapus status tayangan sebelum tanggal before
+func (ac *AiringStateController) Prune(before time.Time) error {
+	return ac.store().DeleteAiringStatesBefore(before.Format("2006-01-02"))
+}
@@ -3,96 +3,184 @@ package controllers
 import (
 	"anime-reminder/database"
 	"anime-reminder/models"
+	"anime-reminder/store"
 	"anime-reminder/utils"
 	"errors"
+	"strings"
 	"time"
 )
 
-type AnimeController struct{}
+// AnimeController berisi logic anime di atas sebuah store.AnimeStore.
+// Zero value tetap bisa dipakai dan jatuh ke store SQLite default.
+type AnimeController struct {
+	Store store.AnimeStore
+	// OnChange dipanggil setelah data anime berhasil diubah (opsional),
+	// dipakai scheduler untuk menyusun ulang antrian reminder
+	OnChange func()
+}
 
-func (ac *AnimeController) Create(title, day, imagePath string, animeTime time.Time, ringToneId uint) (*models.Anime, error) {
-	db := database.GetDB()
-	validDay := false
-	for _, d := range models.Days {
-		if d == day {
-			validDay = true
-			break
-		}
+// NewAnimeController membuat controller dengan store yang di-inject
+func NewAnimeController(s store.AnimeStore) *AnimeController {
+	return &AnimeController{Store: s}
+}
+
+func (ac *AnimeController) store() store.AnimeStore {
+	if ac.Store == nil {
+		return store.NewGormStore(database.GetDB())
 	}
-	if !validDay {
-		return nil, errors.New("invalid day")
+	return ac.Store
+}
+
+// changed memberi tahu listener bahwa data anime berubah
+func (ac *AnimeController) changed() {
+	if ac.OnChange != nil {
+		ac.OnChange()
 	}
+}
 
-	anime := models.Anime{
-		Title:      title,
-		Day:        day,
-		Time:       animeTime,
-		ImagePath:  imagePath,
-		RingToneId: ringToneId,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+// validateSeason memastikan batas musim masuk akal
+func validateSeason(season models.Season) error {
+	if season.EpisodeCount < 0 {
+		return errors.New("episode count cannot be negative")
+	}
+	if season.PremiereDate != nil && season.EndDate != nil && season.EndDate.Before(*season.PremiereDate) {
+		return errors.New("end date cannot be before premiere date")
 	}
+	return nil
+}
 
-	result := db.Create(&anime)
-	if result.Error != nil {
-		return nil, result.Error
+// validateOffsets memastikan advance reminder valid, lalu mengurutkannya
+func validateOffsets(offsets []models.ReminderOffset) error {
+	if err := models.ValidateOffsets(offsets); err != nil {
+		return err
 	}
-	return &anime, nil
+	models.SortOffsets(offsets)
+	return nil
 }
 
-func (ac *AnimeController) GetAnimeById(id uint) (*models.Anime, error) {
-	db := database.GetDB()
-	var anime models.Anime
-	result := db.First(&anime, id)
-	if result.Error != nil {
-		return nil, result.Error
+// validateStreamURL memastikan link nonton kosong atau berupa URL http(s)
+func validateStreamURL(streamURL string) error {
+	if streamURL == "" {
+		return nil
 	}
-	return &anime, nil
+	if !strings.HasPrefix(streamURL, "http://") && !strings.HasPrefix(streamURL, "https://") {
+		return errors.New("stream link must start with http:// or https://")
+	}
+	return nil
 }
 
-func (ac *AnimeController) GetAnimeByTitle(title string) (*models.Anime, error) {
-	db := database.GetDB()
-	var anime models.Anime
-	result := db.Where("title = ?", title).First(&anime)
-	if result.Error != nil {
-		return nil, result.Error
+// errInvalidSlotTime dikembalikan untuk jam slot di luar 00:00-29:59
+var errInvalidSlotTime = errors.New("invalid slot time (HH:MM, 00-29)")
+
+// validateSlots memastikan ada minimal satu slot dan setiap slot punya hari yang valid.
+// Jam 24-29 (time.Date menggulungnya ke tanggal 2 Januari) diubah ke bentuk
+// kanonik seperti models.NewScheduleSlot, langsung di dalam slots.
+func validateSlots(slots []models.ScheduleSlot) error {
+	if len(slots) == 0 {
+		return errors.New("at least one schedule slot is required")
 	}
-	return &anime, nil
+	for i := range slots {
+		slot := &slots[i]
+		if !slot.Day.Valid() {
+			return errors.New("invalid day")
+		}
+
+		switch {
+		case slot.Time.YearDay() == 1:
+			// Sudah kanonik
+		case slot.Time.YearDay() == 2 && !slot.LateNight:
+			parsed, err := models.NewScheduleSlot(slot.Label, slot.Day, 24+slot.Time.Hour(), slot.Time.Minute())
+			if err != nil {
+				return errInvalidSlotTime
+			}
+			slot.Day, slot.Time, slot.LateNight = parsed.Day, parsed.Time, parsed.LateNight
+		default:
+			return errInvalidSlotTime
+		}
+	}
+	return nil
 }
 
-func (ac *AnimeController) GetAllAnimes() ([]models.Anime, error) {
-	db := database.GetDB()
-	var animes []models.Anime
-	result := db.Find(&animes)
-	if result.Error != nil {
-		return nil, result.Error
+// AnimeInput adalah data anime dari form tambah/edit
+type AnimeInput struct {
+	Title     string
+	ImagePath string
+	Timezone  string
+	// StreamURL kosong berarti tidak ada link nonton
+	StreamURL  string
+	Slots      []models.ScheduleSlot
+	RingToneId uint
+	Season     models.Season
+	Offsets    []models.ReminderOffset
+}
+
+// validate memeriksa input; slot dan offset dinormalisasi langsung di slice-nya
+func (in AnimeInput) validate() error {
+	if _, err := models.LoadTimezone(in.Timezone); err != nil {
+		return err
+	}
+	if err := validateSlots(in.Slots); err != nil {
+		return err
+	}
+	if err := validateSeason(in.Season); err != nil {
+		return err
+	}
+	if err := validateOffsets(in.Offsets); err != nil {
+		return err
 	}
-	return animes, nil
+	return validateStreamURL(in.StreamURL)
 }
 
-func (ac *AnimeController) UpdateAnime(title, day, imagePath string, animeTime time.Time, animeID, ringToneId uint) (*models.Anime, error) {
-	db := database.GetDB()
-	var anime models.Anime
-	result := db.First(&anime, animeID)
-	if result.Error != nil {
-		return nil, result.Error
+func (ac *AnimeController) Create(input AnimeInput) (*models.Anime, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
 	}
 
-	// Validasi day
-	validDay := false
-	for _, d := range models.Days {
-		if d == day {
-			validDay = true
-			break
-		}
+	anime := models.Anime{
+		Title:           input.Title,
+		Timezone:        input.Timezone,
+		Slots:           input.Slots,
+		ImagePath:       input.ImagePath,
+		StreamURL:       input.StreamURL,
+		RingToneId:      input.RingToneId,
+		Season:          input.Season,
+		ReminderOffsets: input.Offsets,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	if err := ac.store().CreateAnime(&anime); err != nil {
+		return nil, err
+	}
+	ac.changed()
+	return &anime, nil
+}
+
+func (ac *AnimeController) GetAnimeById(id uint) (*models.Anime, error) {
+	return ac.store().GetAnime(id)
+}
+
+func (ac *AnimeController) GetAnimeByTitle(title string) (*models.Anime, error) {
+	return ac.store().GetAnimeByTitle(title)
+}
+
+func (ac *AnimeController) GetAllAnimes() ([]models.Anime, error) {
+	return ac.store().ListAnimes()
+}
+
+func (ac *AnimeController) UpdateAnime(animeID uint, input AnimeInput) (*models.Anime, error) {
+	anime, err := ac.store().GetAnime(animeID)
+	if err != nil {
+		return nil, err
 	}
-	if !validDay {
-		return nil, errors.New("invalid day")
+
+	if err := input.validate(); err != nil {
+		return nil, err
 	}
 
 	// HAPUS FILE LAMA jika ada file baru yang berbeda
-	if imagePath != "" && imagePath != anime.ImagePath {
-		err := utils.DeleteOldFileIfDifferent(anime.ImagePath, imagePath)
+	if input.ImagePath != "" && input.ImagePath != anime.ImagePath {
+		err := utils.DeleteOldFileIfDifferent(anime.ImagePath, input.ImagePath)
 		if err != nil {
 			// Log error tapi tetap lanjutkan update
 			// (file mungkin sudah terhapus manual)
@@ -100,33 +188,52 @@ func (ac *AnimeController) UpdateAnime(title, day, imagePath string, animeTime t
 	}
 
 	// Update data
-	animeInput := map[string]interface{}{
-		"Title":      title,
-		"Day":        day,
-		"Time":       animeTime,
-		"ImagePath":  imagePath,
-		"RingToneId": ringToneId,
-		"UpdatedAt":  time.Now(),
-	}
+	anime.Title = input.Title
+	anime.Timezone = input.Timezone
+	anime.Slots = input.Slots
+	anime.ImagePath = input.ImagePath
+	anime.StreamURL = input.StreamURL
+	anime.RingToneId = input.RingToneId
+	anime.Season = input.Season
+	anime.ReminderOffsets = input.Offsets
+	anime.UpdatedAt = time.Now()
+
+	// Musim diperpanjang: aktifkan lagi anime yang sudah di-archive
+	if anime.Archived && !anime.HasEnded(time.Now()) {
+		anime.Archived = false
+	}
+
+	if err := ac.store().UpdateAnime(anime); err != nil {
+		return nil, err
+	}
+	ac.changed()
+	return anime, nil
+}
 
-	result = db.Model(&anime).Updates(animeInput)
-	if result.Error != nil {
-		return nil, result.Error
+// SetArchived meng-archive (atau mengaktifkan lagi) sebuah anime
+func (ac *AnimeController) SetArchived(id uint, archived bool) error {
+	anime, err := ac.store().GetAnime(id)
+	if err != nil {
+		return err
+	}
+	if anime.Archived == archived {
+		return nil
 	}
 
-	// Reload data untuk mendapatkan nilai terbaru
-	db.First(&anime, animeID)
-	return &anime, nil
+	anime.Archived = archived
+	anime.UpdatedAt = time.Now()
+	if err := ac.store().UpdateAnime(anime); err != nil {
+		return err
+	}
+	ac.changed()
+	return nil
 }
 
 func (ac *AnimeController) DeleteAnime(id uint) error {
-	db := database.GetDB()
-
 	// Ambil data anime dulu untuk mendapatkan path file
-	var anime models.Anime
-	result := db.First(&anime, id)
-	if result.Error != nil {
-		return result.Error
+	anime, err := ac.store().GetAnime(id)
+	if err != nil {
+		return err
 	}
 
 	// Hapus file gambar jika ada
@@ -137,11 +244,10 @@ func (ac *AnimeController) DeleteAnime(id uint) error {
 		}
 	}
 
-	// Delete dari database
-	result = db.Delete(&models.Anime{}, id)
-	if result.Error != nil {
-		return result.Error
+	// Delete dari store
+	if err := ac.store().DeleteAnime(id); err != nil {
+		return err
 	}
-
+	ac.changed()
 	return nil
 }
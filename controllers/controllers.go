@@ -0,0 +1,46 @@
+package controllers
+
+import "anime-reminder/store"
+
+// Controllers mengelompokkan semua controller supaya mudah di-inject ke UI dan scheduler
+type Controllers struct {
+	Anime    *AnimeController
+	RingTone *RingToneController
+	Episode  *EpisodeController
+	History  *HistoryController
+	Snooze   *SnoozeController
+	Airing   *AiringStateController
+
+	changes chan struct{}
+}
+
+// NewControllers membuat semua controller di atas satu store
+func NewControllers(s store.Store) *Controllers {
+	c := &Controllers{
+		Anime:    NewAnimeController(s),
+		RingTone: NewRingToneController(s),
+		Episode:  NewEpisodeController(s),
+		History:  NewHistoryController(s),
+		Snooze:   NewSnoozeController(s),
+		Airing:   NewAiringStateController(s),
+		changes:  make(chan struct{}, 1),
+	}
+	c.Anime.OnChange = c.NotifyChange
+	c.Snooze.OnChange = c.NotifyChange
+	return c
+}
+
+// NotifyChange mengirim sinyal perubahan tanpa blocking, juga dipakai UI
+// setelah mengubah pengaturan yang mempengaruhi jadwal.
+// Beberapa perubahan beruntun cukup diwakili satu sinyal.
+func (c *Controllers) NotifyChange() {
+	select {
+	case c.changes <- struct{}{}:
+	default:
+	}
+}
+
+// Changes returns a channel that receives a signal whenever anime or snooze data changes
+func (c *Controllers) Changes() <-chan struct{} {
+	return c.changes
+}
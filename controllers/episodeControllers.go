@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"anime-reminder/database"
+	"anime-reminder/models"
+	"anime-reminder/store"
+	"errors"
+	"time"
+)
+
+// EpisodeController mengelola episode per anime (generate jadwal, aired, watched)
+type EpisodeController struct {
+	Store store.EpisodeStore
+}
+
+// NewEpisodeController membuat controller dengan store yang di-inject
+func NewEpisodeController(s store.EpisodeStore) *EpisodeController {
+	return &EpisodeController{Store: s}
+}
+
+func (ec *EpisodeController) store() store.EpisodeStore {
+	if ec.Store == nil {
+		return store.NewGormStore(database.GetDB())
+	}
+	return ec.Store
+}
+
+// GenerateEpisodes membuat jadwal count episode mulai dari tayangan pertama
+// pada atau setelah startDate, mengikuti slot utama anime (lihat Anime.PrimarySlot).
+// Status watched dan judul episode lama dipertahankan berdasarkan nomor episode.
+func (ec *EpisodeController) GenerateEpisodes(anime *models.Anime, startDate time.Time, count int) ([]models.Episode, error) {
+	if count <= 0 {
+		return nil, errors.New("episode count must be greater than zero")
+	}
+
+	slot, ok := anime.PrimarySlot()
+	if !ok {
+		return nil, errors.New("anime has no schedule slot")
+	}
+	if !slot.Day.Valid() {
+		return nil, errors.New("invalid day")
+	}
+
+	existing, err := ec.store().ListEpisodes(anime.Id)
+	if err != nil {
+		return nil, err
+	}
+	oldByNumber := make(map[int]models.Episode, len(existing))
+	for _, episode := range existing {
+		oldByNumber[episode.Number] = episode
+	}
+
+	// Cari tanggal tayang pertama yang harinya cocok (tanggal dibaca di zona siaran)
+	loc := anime.Location()
+	first := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	for models.WeekdayOf(first) != slot.Day {
+		first = first.AddDate(0, 0, 1)
+	}
+
+	now := time.Now()
+	episodes := make([]models.Episode, count)
+	for i := 0; i < count; i++ {
+		// AddDate per minggu supaya jam tetap benar walaupun ada pergantian DST
+		day := first.AddDate(0, 0, 7*i)
+		airAt := time.Date(day.Year(), day.Month(), day.Day(), slot.Time.Hour(), slot.Time.Minute(), 0, 0, loc)
+
+		episode := models.Episode{
+			Number: i + 1,
+			AirAt:  airAt,
+			Aired:  !airAt.After(now),
+		}
+		if old, ok := oldByNumber[episode.Number]; ok {
+			episode.Title = old.Title
+			episode.Watched = old.Watched
+			episode.WatchedAt = old.WatchedAt
+		}
+		episodes[i] = episode
+	}
+
+	if err := ec.store().ReplaceEpisodes(anime.Id, episodes); err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+func (ec *EpisodeController) GetEpisodes(animeID uint) ([]models.Episode, error) {
+	return ec.store().ListEpisodes(animeID)
+}
+
+// SetWatched menandai episode sudah/belum ditonton
+func (ec *EpisodeController) SetWatched(episode *models.Episode, watched bool) error {
+	episode.Watched = watched
+	if watched {
+		now := time.Now()
+		episode.WatchedAt = &now
+	} else {
+		episode.WatchedAt = nil
+	}
+	return ec.store().UpdateEpisode(episode)
+}
+
+// MarkAired menandai episode sebagai sudah tayang
+func (ec *EpisodeController) MarkAired(episode *models.Episode) error {
+	if episode.Aired {
+		return nil
+	}
+	episode.Aired = true
+	return ec.store().UpdateEpisode(episode)
+}
+
+// CurrentEpisode mencari episode yang tayang pada at: episode terakhir yang
+// jadwalnya paling lambat 1 jam setelah at dan tidak lebih dari seminggu sebelumnya
+// (slot kedua seperti rilis streaming biasanya menyusul slot utama).
+// Mengembalikan nil jika tidak ada, beserta jumlah total episode.
+func (ec *EpisodeController) CurrentEpisode(animeID uint, at time.Time) (*models.Episode, int, error) {
+	episodes, err := ec.store().ListEpisodes(animeID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var current *models.Episode
+	for i := range episodes {
+		diff := at.Sub(episodes[i].AirAt)
+		if diff < -time.Hour || diff >= 7*24*time.Hour {
+			continue
+		}
+		if current == nil || episodes[i].AirAt.After(current.AirAt) {
+			current = &episodes[i]
+		}
+	}
+	return current, len(episodes), nil
+}
+
+// Backlog menghitung episode yang sudah tayang tapi belum ditonton
+func (ec *EpisodeController) Backlog(animeID uint) (int, error) {
+	episodes, err := ec.store().ListEpisodes(animeID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	count := 0
+	for _, episode := range episodes {
+		if episode.HasAired(now) && !episode.Watched {
+			count++
+		}
+	}
+	return count, nil
+}
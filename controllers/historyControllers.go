@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"anime-reminder/database"
+	"anime-reminder/models"
+	"anime-reminder/store"
+)
+
+// HistoryController mengelola history reminder (tabel reminder_events)
+type HistoryController struct {
+	Store store.HistoryStore
+}
+
+// NewHistoryController membuat controller dengan store yang di-inject
+func NewHistoryController(s store.HistoryStore) *HistoryController {
+	return &HistoryController{Store: s}
+}
+
+func (hc *HistoryController) store() store.HistoryStore {
+	if hc.Store == nil {
+		return store.NewGormStore(database.GetDB())
+	}
+	return hc.Store
+}
+
+// Record menyimpan reminder yang baru saja di-fire
+func (hc *HistoryController) Record(event *models.ReminderEvent) error {
+	return hc.store().CreateReminderEvent(event)
+}
+
+// AddDeliveries mencatat hasil pengiriman yang selesai setelah event disimpan
+func (hc *HistoryController) AddDeliveries(eventID uint, deliveries []models.ReminderDelivery) error {
+	return hc.store().AddReminderDeliveries(eventID, deliveries)
+}
+
+func (hc *HistoryController) GetEventById(id uint) (*models.ReminderEvent, error) {
+	return hc.store().GetReminderEvent(id)
+}
+
+// GetEvents mengembalikan history sesuai filter, terbaru lebih dulu
+func (hc *HistoryController) GetEvents(filter models.ReminderEventFilter) ([]models.ReminderEvent, error) {
+	return hc.store().ListReminderEvents(filter)
+}
@@ -3,60 +3,67 @@ package controllers
 import (
 	"anime-reminder/database"
 	"anime-reminder/models"
+	"anime-reminder/store"
 	"anime-reminder/utils"
 	"fmt"
 )
 
-type RingToneController struct{}
+// RingToneController berisi logic ringtone di atas sebuah store.RingToneStore.
+// Zero value tetap bisa dipakai dan jatuh ke store SQLite default.
+type RingToneController struct {
+	Store store.RingToneStore
+}
 
-func (rc *RingToneController) Create(name, songPath string) (*models.RingTone, error) {
-	db := database.GetDB()
+// NewRingToneController membuat controller dengan store yang di-inject
+func NewRingToneController(s store.RingToneStore) *RingToneController {
+	return &RingToneController{Store: s}
+}
+
+func (rc *RingToneController) store() store.RingToneStore {
+	if rc.Store == nil {
+		return store.NewGormStore(database.GetDB())
+	}
+	return rc.Store
+}
 
+func (rc *RingToneController) Create(name, songPath string) (*models.RingTone, error) {
 	ringTone := models.RingTone{
 		Name:     name,
 		SongPath: songPath,
 	}
 
-	result := db.Create(&ringTone)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return nil, result.Error
+	if err := rc.store().CreateRingTone(&ringTone); err != nil {
+		fmt.Println(err)
+		return nil, err
 	}
 	return &ringTone, nil
 }
 
 func (rc *RingToneController) GetAllRingTone() ([]models.RingTone, error) {
-	db := database.GetDB()
-	var ringTones []models.RingTone
-	result := db.Find(&ringTones)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return nil, result.Error
+	ringTones, err := rc.store().ListRingTones()
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
 	}
 	return ringTones, nil
 }
 
 func (rc *RingToneController) GetRingToneById(id uint) (*models.RingTone, error) {
-	db := database.GetDB()
-	var ringTone models.RingTone
-	result := db.First(&ringTone, id)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return nil, result.Error
+	ringTone, err := rc.store().GetRingTone(id)
+	if err != nil {
+		fmt.Println(err)
+		return nil, err
 	}
-	return &ringTone, nil
+	return ringTone, nil
 }
 
 // UpdateRingTone - dengan penghapusan file lama
 func (rc *RingToneController) UpdateRingTone(ringTone *models.RingTone) error {
-	db := database.GetDB()
-
 	// Ambil data lama untuk mendapatkan path file lama
-	var oldRingTone models.RingTone
-	result := db.First(&oldRingTone, ringTone.Id)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return result.Error
+	oldRingTone, err := rc.store().GetRingTone(ringTone.Id)
+	if err != nil {
+		fmt.Println(err)
+		return err
 	}
 
 	// HAPUS FILE LAMA jika ada file baru yang berbeda
@@ -72,23 +79,19 @@ func (rc *RingToneController) UpdateRingTone(ringTone *models.RingTone) error {
 	}
 
 	// Update data
-	result = db.Save(ringTone)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return result.Error
+	if err := rc.store().UpdateRingTone(ringTone); err != nil {
+		fmt.Println(err)
+		return err
 	}
 	return nil
 }
 
 func (rc *RingToneController) DeleteRingTone(id uint) error {
-	db := database.GetDB()
-
 	// Ambil data ringtone dulu untuk mendapatkan path file
-	var ringTone models.RingTone
-	result := db.First(&ringTone, id)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return result.Error
+	ringTone, err := rc.store().GetRingTone(id)
+	if err != nil {
+		fmt.Println(err)
+		return err
 	}
 
 	// Stop audio player jika sedang play file ini
@@ -103,11 +106,10 @@ func (rc *RingToneController) DeleteRingTone(id uint) error {
 		}
 	}
 
-	// Delete dari database
-	result = db.Delete(&models.RingTone{}, id)
-	if result.Error != nil {
-		fmt.Println(result.Error)
-		return result.Error
+	// Delete dari store
+	if err := rc.store().DeleteRingTone(id); err != nil {
+		fmt.Println(err)
+		return err
 	}
 	return nil
 }
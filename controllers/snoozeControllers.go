@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"anime-reminder/database"
+	"anime-reminder/models"
+	"anime-reminder/store"
+	"errors"
+	"time"
+)
+
+// MaxSnooze adalah snooze terlama yang diizinkan
+const MaxSnooze = 24 * time.Hour
+
+// SnoozeController mengelola reminder yang di-snooze
+type SnoozeController struct {
+	Store store.SnoozeStore
+	// OnChange dipanggil setelah daftar snooze berubah (opsional)
+	OnChange func()
+}
+
+// NewSnoozeController membuat controller dengan store yang di-inject
+func NewSnoozeController(s store.SnoozeStore) *SnoozeController {
+	return &SnoozeController{Store: s}
+}
+
+func (sc *SnoozeController) store() store.SnoozeStore {
+	if sc.Store == nil {
+		return store.NewGormStore(database.GetDB())
+	}
+	return sc.Store
+}
+
+func (sc *SnoozeController) changed() {
+	if sc.OnChange != nil {
+		sc.OnChange()
+	}
+}
+
+// Snooze menunda event selama d. Snooze lama untuk event yang sama diganti.
+func (sc *SnoozeController) Snooze(event models.ReminderEvent, d time.Duration) (*models.Snooze, error) {
+	if event.Id == 0 {
+		return nil, errors.New("reminder has not been recorded yet")
+	}
+	if d < time.Minute || d > MaxSnooze {
+		return nil, errors.New("snooze must be between 1 minute and 24 hours")
+	}
+
+	if err := sc.store().DeleteEventSnoozes(event.Id); err != nil {
+		return nil, err
+	}
+
+	snooze := models.Snooze{
+		EventId:   event.Id,
+		AnimeId:   event.AnimeId,
+		SlotId:    event.SlotId,
+		Until:     time.Now().Add(d),
+		CreatedAt: time.Now(),
+	}
+	if err := sc.store().CreateSnooze(&snooze); err != nil {
+		return nil, err
+	}
+	sc.changed()
+	return &snooze, nil
+}
+
+// Dismiss membatalkan snooze yang masih menunggu untuk event
+func (sc *SnoozeController) Dismiss(eventID uint) error {
+	if err := sc.store().DeleteEventSnoozes(eventID); err != nil {
+		return err
+	}
+	sc.changed()
+	return nil
+}
+
+// Pending mengembalikan semua snooze, paling cepat lebih dulu
+func (sc *SnoozeController) Pending() ([]models.Snooze, error) {
+	return sc.store().ListSnoozes()
+}
+
+// Done menghapus snooze yang sudah dikirim ulang
+func (sc *SnoozeController) Done(id uint) error {
+	return sc.store().DeleteSnooze(id)
+}
@@ -1,7 +1,7 @@
 package database
 
 import (
-	"anime-reminder/models"
+	"anime-reminder/utils"
 	"fmt"
 	"log"
 
@@ -11,23 +11,21 @@ import (
 
 var db *gorm.DB
 
-// init() akan dipanggil otomatis saat package di-import
-func init() {
+// InitDB membuka database di data directory dan menjalankan migration (dipanggil dari main.go)
+func InitDB() error {
+	dbPath := utils.DataPath(utils.DatabaseFileName)
+
 	var err error
-	db, err = gorm.Open(sqlite.Open("anime_reminder.db"), &gorm.Config{})
+	db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
 	if err != nil {
-		fmt.Println("Error opening database:", err)
-		return
+		return fmt.Errorf("error opening database %s: %v", dbPath, err)
 	}
-	migrate()
-}
 
-// InitDB sebagai wrapper untuk kompatibilitas (dipanggil dari main.go)
-func InitDB() error {
-	if db == nil {
-		return fmt.Errorf("database not initialized")
+	if err := migrate(); err != nil {
+		return err
 	}
-	log.Println("✅ Database initialized successfully")
+
+	log.Printf("✅ Database initialized successfully: %s", dbPath)
 	return nil
 }
 
@@ -51,12 +49,13 @@ func CloseDB() error {
 	return sqlDB.Close()
 }
 
-// migrate runs auto-migration for models
-func migrate() {
-	err := db.AutoMigrate(&models.Anime{}, &models.RingTone{})
+// migrate runs the versioned schema migrations
+func migrate() error {
+	err := Migrate(db)
 	if err != nil {
 		fmt.Println("Migration error:", err)
-	} else {
-		log.Println("✅ Database migration completed")
+		return err
 	}
+	log.Println("✅ Database migration completed")
+	return nil
 }
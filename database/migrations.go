@@ -0,0 +1,356 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrDatabaseTooNew dikembalikan jika database dibuat oleh versi aplikasi yang lebih baru
+var ErrDatabaseTooNew = errors.New("database schema is newer than this application")
+
+// Migration adalah satu langkah perubahan schema yang berurutan
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+}
+
+// SchemaMigration mencatat migration yang sudah dijalankan
+type SchemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:255"`
+	AppliedAt time.Time
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migrations berisi semua migration, urut berdasarkan Version.
+// Jangan pernah mengubah migration yang sudah dirilis, tambahkan yang baru di akhir.
+// Setiap migration memakai struct snapshot sendiri supaya tidak ikut berubah
+// ketika struct di package models berubah.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			type Anime struct {
+				Id         uint   `gorm:"primary_key;auto_increment"`
+				Title      string `gorm:"size:255"`
+				Day        string `gorm:"size:50"`
+				Time       time.Time
+				ImagePath  string `gorm:"size:500"`
+				RingToneId uint
+				CreatedAt  time.Time
+				UpdatedAt  time.Time
+			}
+			type RingTone struct {
+				Id        uint `gorm:"primary_key;auto_increment"`
+				Name      string
+				SongPath  string
+				CreatedAt time.Time
+				UpdatedAt time.Time
+			}
+			return tx.AutoMigrate(&Anime{}, &RingTone{})
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_episodes",
+		Up: func(tx *gorm.DB) error {
+			type Episode struct {
+				Id        uint `gorm:"primary_key;auto_increment"`
+				AnimeId   uint `gorm:"index"`
+				Number    int
+				Title     string `gorm:"size:255"`
+				AirAt     time.Time
+				Aired     bool
+				Watched   bool
+				WatchedAt *time.Time
+				CreatedAt time.Time
+				UpdatedAt time.Time
+			}
+			return tx.AutoMigrate(&Episode{})
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_anime_season_bounds",
+		Up: func(tx *gorm.DB) error {
+			type Anime struct {
+				Id           uint   `gorm:"primary_key;auto_increment"`
+				Title        string `gorm:"size:255"`
+				Day          string `gorm:"size:50"`
+				Time         time.Time
+				ImagePath    string `gorm:"size:500"`
+				RingToneId   uint
+				PremiereDate *time.Time
+				EpisodeCount int
+				EndDate      *time.Time
+				Archived     bool
+				CreatedAt    time.Time
+				UpdatedAt    time.Time
+			}
+			if err := tx.AutoMigrate(&Anime{}); err != nil {
+				return err
+			}
+			// Kolom baru berisi NULL untuk data lama
+			return tx.Exec("UPDATE animes SET episode_count = 0, archived = false WHERE archived IS NULL").Error
+		},
+	},
+	{
+		Version: 4,
+		Name:    "create_reminder_events",
+		Up: func(tx *gorm.DB) error {
+			type ReminderEvent struct {
+				Id            uint   `gorm:"primary_key;auto_increment"`
+				AnimeId       uint   `gorm:"index"`
+				AnimeTitle    string `gorm:"size:255"`
+				EpisodeNumber int
+				Title         string `gorm:"size:255"`
+				Message       string
+				FiredAt       time.Time `gorm:"index"`
+				AudioPlayed   bool
+				RingToneName  string `gorm:"size:255"`
+				ResentFromId  uint
+				CreatedAt     time.Time
+			}
+			type ReminderDelivery struct {
+				Id      uint   `gorm:"primary_key;auto_increment"`
+				EventId uint   `gorm:"index"`
+				Channel string `gorm:"size:50"`
+				Success bool
+				Error   string
+			}
+			return tx.AutoMigrate(&ReminderEvent{}, &ReminderDelivery{})
+		},
+	},
+	{
+		Version: 5,
+		Name:    "move_day_time_to_schedule_slots",
+		Up: func(tx *gorm.DB) error {
+			type ScheduleSlot struct {
+				Id         uint   `gorm:"primary_key;auto_increment"`
+				AnimeId    uint   `gorm:"index"`
+				Label      string `gorm:"size:50"`
+				Day        string `gorm:"size:50"`
+				Time       time.Time
+				RingToneId uint
+				Enabled    bool
+				CreatedAt  time.Time
+				UpdatedAt  time.Time
+			}
+			if err := tx.AutoMigrate(&ScheduleSlot{}); err != nil {
+				return err
+			}
+
+			// Satu slot "TV" untuk setiap anime dari kolom day/time lama
+			err := tx.Exec(`INSERT INTO schedule_slots (anime_id, label, day, time, ring_tone_id, enabled, created_at, updated_at)
+				SELECT id, 'TV', day, time, 0, true, created_at, updated_at
+				FROM animes WHERE day IS NOT NULL AND day != ''`).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Exec("ALTER TABLE animes DROP COLUMN `day`").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("ALTER TABLE animes DROP COLUMN `time`").Error; err != nil {
+				return err
+			}
+
+			return tx.Exec("ALTER TABLE reminder_events ADD COLUMN slot_id integer DEFAULT 0").Error
+		},
+	},
+	{
+		Version: 6,
+		Name:    "add_anime_timezone",
+		Up: func(tx *gorm.DB) error {
+			// Kosong berarti waktu lokal, sama seperti perilaku sebelumnya
+			return tx.Exec("ALTER TABLE animes ADD COLUMN timezone text DEFAULT ''").Error
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_slot_late_night",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE schedule_slots ADD COLUMN late_night numeric DEFAULT false").Error
+		},
+	},
+	{
+		Version: 8,
+		Name:    "convert_slot_day_to_weekday",
+		Up: func(tx *gorm.DB) error {
+			// Nama hari (bahasa Indonesia, juga variasi huruf kecil/Inggris) menjadi
+			// angka time.Weekday: Minggu = 0 ... Sabtu = 6. Nama yang tidak dikenal menjadi -1,
+			// nama aslinya disimpan di invalid_day supaya user bisa memperbaikinya di slot editor.
+			if err := tx.Exec("ALTER TABLE schedule_slots ADD COLUMN weekday integer DEFAULT -1").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("ALTER TABLE schedule_slots ADD COLUMN invalid_day varchar(50) DEFAULT ''").Error; err != nil {
+				return err
+			}
+			err := tx.Exec(`UPDATE schedule_slots SET weekday = CASE lower(trim(day))
+				WHEN 'minggu' THEN 0 WHEN 'sunday' THEN 0
+				WHEN 'senin' THEN 1 WHEN 'monday' THEN 1
+				WHEN 'selasa' THEN 2 WHEN 'tuesday' THEN 2
+				WHEN 'rabu' THEN 3 WHEN 'wednesday' THEN 3
+				WHEN 'kamis' THEN 4 WHEN 'thursday' THEN 4
+				WHEN 'jumat' THEN 5 WHEN 'friday' THEN 5
+				WHEN 'sabtu' THEN 6 WHEN 'saturday' THEN 6
+				ELSE -1 END`).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Exec("UPDATE schedule_slots SET invalid_day = COALESCE(day, '') WHERE weekday = -1").Error; err != nil {
+				return err
+			}
+
+			type unknownDay struct {
+				Id      uint
+				AnimeId uint
+				Day     string
+			}
+			var unknown []unknownDay
+			if err := tx.Table("schedule_slots").Select("id, anime_id, day").Where("weekday = -1").Scan(&unknown).Error; err != nil {
+				return err
+			}
+			for _, slot := range unknown {
+				log.Printf("⚠️ Anime %d slot %d has an unknown day %q, please pick a day in the slot editor", slot.AnimeId, slot.Id, slot.Day)
+			}
+
+			if err := tx.Exec("ALTER TABLE schedule_slots DROP COLUMN `day`").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE schedule_slots RENAME COLUMN weekday TO `day`").Error
+		},
+	},
+	{
+		Version: 9,
+		Name:    "add_reminder_offsets",
+		Up: func(tx *gorm.DB) error {
+			// Daftar offset disimpan sebagai JSON, kosong berarti pakai default global
+			if err := tx.Exec("ALTER TABLE animes ADD COLUMN reminder_offsets text DEFAULT ''").Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE reminder_events ADD COLUMN offset_minutes integer DEFAULT 0").Error
+		},
+	},
+	{
+		Version: 10,
+		Name:    "create_snoozes",
+		Up: func(tx *gorm.DB) error {
+			type Snooze struct {
+				Id        uint `gorm:"primary_key;auto_increment"`
+				EventId   uint `gorm:"index"`
+				AnimeId   uint
+				SlotId    uint
+				Until     time.Time `gorm:"index"`
+				CreatedAt time.Time
+			}
+			return tx.AutoMigrate(&Snooze{})
+		},
+	},
+	{
+		Version: 11,
+		Name:    "create_airing_states",
+		Up: func(tx *gorm.DB) error {
+			type AiringState struct {
+				Id             uint   `gorm:"primary_key;auto_increment"`
+				AnimeId        uint   `gorm:"uniqueIndex:idx_airing_state"`
+				SlotId         uint   `gorm:"uniqueIndex:idx_airing_state"`
+				AirDate        string `gorm:"size:10;uniqueIndex:idx_airing_state"`
+				OffsetMinutes  int    `gorm:"uniqueIndex:idx_airing_state"`
+				FiredAt        *time.Time
+				AcknowledgedAt *time.Time
+				UpdatedAt      time.Time
+			}
+			if err := tx.AutoMigrate(&AiringState{}); err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE reminder_events ADD COLUMN air_date varchar(10) DEFAULT ''").Error
+		},
+	},
+	{
+		Version: 12,
+		Name:    "add_stream_url",
+		Up: func(tx *gorm.DB) error {
+			return tx.Exec("ALTER TABLE animes ADD COLUMN stream_url varchar(500) DEFAULT ''").Error
+		},
+	},
+}
+
+// LatestSchemaVersion returns the schema version this binary knows about
+func LatestSchemaVersion() int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the highest migration version applied to conn
+func SchemaVersion(conn *gorm.DB) (int, error) {
+	if !conn.Migrator().HasTable(&SchemaMigration{}) {
+		return 0, nil
+	}
+
+	var version int
+	result := conn.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return version, nil
+}
+
+// Migrate menjalankan semua migration yang belum diterapkan, masing-masing dalam transaksi
+func Migrate(conn *gorm.DB) error {
+	return migrateTo(conn, LatestSchemaVersion())
+}
+
+// migrateTo menjalankan migration sampai target (inklusif), dipakai test untuk
+// membuat database di versi schema lama
+func migrateTo(conn *gorm.DB, target int) error {
+	if err := conn.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %v", err)
+	}
+
+	current, err := SchemaVersion(conn)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+
+	latest := LatestSchemaVersion()
+	if current > latest {
+		return fmt.Errorf("%w (database version %d, supported version %d)", ErrDatabaseTooNew, current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current || m.Version > target {
+			continue
+		}
+
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %v", m.Version, m.Name, err)
+		}
+
+		log.Printf("✅ Applied migration %d: %s", m.Version, m.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+package database
+
+import (
+	"anime-reminder/models"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return conn
+}
+
+func clockAt(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func mustExec(t *testing.T, conn *gorm.DB, sql string, values ...interface{}) {
+	t.Helper()
+	if err := conn.Exec(sql, values...).Error; err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+func mustMigrateTo(t *testing.T, conn *gorm.DB, version int) {
+	t.Helper()
+	if err := migrateTo(conn, version); err != nil {
+		t.Fatalf("migrate to %d: %v", version, err)
+	}
+}
+
+// fixtureAnime adalah anime yang di-seed dalam bentuk schema versi lama
+type fixtureAnime struct {
+	title string
+	// dayName adalah nama hari sebelum migration 8, weekday dan invalidDay sesudahnya
+	dayName    string
+	weekday    models.Weekday
+	invalidDay string
+	clock      time.Time
+	ringToneId uint
+	timezone   string
+	episodes   int
+	archived   bool
+	offsets    string
+	enabled    bool
+	late       bool
+}
+
+var fixtureAnimes = []fixtureAnime{
+	{title: "Frieren", dayName: "Jumat", weekday: models.Friday, clock: clockAt(23, 0), ringToneId: 1,
+		timezone: "Asia/Tokyo", episodes: 28, offsets: `[{"minutes":15}]`, enabled: true},
+	{title: "Dandadan", dayName: "kamis", weekday: models.Thursday, clock: clockAt(0, 30), archived: true, late: true},
+	{title: "Oshi no Ko", dayName: "Jum'at", weekday: -1, invalidDay: "Jum'at", clock: clockAt(22, 0), enabled: true},
+}
+
+var fixturePremiere = time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC)
+
+// seedVersion membuat database dengan schema versi version (0 = sebelum ada
+// schema_migrations) lalu mengisinya dengan fixtureAnimes dan data runtime
+// yang sudah ada di versi itu, memakai kolom persis seperti saat itu.
+func seedVersion(t *testing.T, conn *gorm.DB, version int) {
+	t.Helper()
+	if version == 0 {
+		if err := migrations[0].Up(conn); err != nil {
+			t.Fatalf("baseline schema: %v", err)
+		}
+	} else {
+		mustMigrateTo(t, conn, version)
+	}
+
+	now := time.Now()
+	mustCreate(t, conn, "ring_tones", map[string]interface{}{
+		"name": "Opening", "song_path": "uploads/op.mp3", "created_at": now, "updated_at": now,
+	})
+
+	for i, fixture := range fixtureAnimes {
+		anime := map[string]interface{}{
+			"title": fixture.title, "image_path": "", "ring_tone_id": fixture.ringToneId,
+			"created_at": now, "updated_at": now,
+		}
+		if version < 5 {
+			anime["day"] = fixture.dayName
+			anime["time"] = fixture.clock
+		}
+		if version >= 3 {
+			anime["episode_count"] = fixture.episodes
+			anime["archived"] = fixture.archived
+			if fixture.episodes > 0 {
+				anime["premiere_date"] = fixturePremiere
+			}
+		}
+		if version >= 6 {
+			anime["timezone"] = fixture.timezone
+		}
+		if version >= 9 {
+			anime["reminder_offsets"] = fixture.offsets
+		}
+		mustCreate(t, conn, "animes", anime)
+
+		if version < 5 {
+			continue
+		}
+		slot := map[string]interface{}{
+			"anime_id": i + 1, "label": "TV", "time": fixture.clock, "ring_tone_id": 0,
+			"enabled": fixture.enabled, "created_at": now, "updated_at": now,
+		}
+		if version < 8 {
+			slot["day"] = fixture.dayName
+		} else {
+			slot["day"] = int(fixture.weekday)
+			slot["invalid_day"] = fixture.invalidDay
+		}
+		if version >= 7 {
+			slot["late_night"] = fixture.late
+		}
+		mustCreate(t, conn, "schedule_slots", slot)
+	}
+
+	if version >= 2 {
+		mustCreate(t, conn, "episodes", map[string]interface{}{
+			"anime_id": 1, "number": 1, "title": "The Journey's End", "air_at": fixturePremiere,
+			"aired": true, "watched": true, "created_at": now, "updated_at": now,
+		})
+	}
+	if version >= 4 {
+		event := map[string]interface{}{
+			"anime_id": 1, "anime_title": "Frieren", "episode_number": 1, "title": "Frieren",
+			"message": "Episode baru", "fired_at": now, "audio_played": true, "ring_tone_name": "Opening",
+			"resent_from_id": 0, "created_at": now,
+		}
+		if version >= 5 {
+			event["slot_id"] = 1
+		}
+		if version >= 9 {
+			event["offset_minutes"] = 15
+		}
+		if version >= 11 {
+			event["air_date"] = "2026-10-03"
+		}
+		mustCreate(t, conn, "reminder_events", event)
+		mustCreate(t, conn, "reminder_deliveries", map[string]interface{}{
+			"event_id": 1, "channel": "dbus", "success": true, "error": "",
+		})
+	}
+	if version >= 10 {
+		mustCreate(t, conn, "snoozes", map[string]interface{}{
+			"event_id": 1, "anime_id": 1, "slot_id": 1, "until": now.Add(10 * time.Minute), "created_at": now,
+		})
+	}
+	if version >= 11 {
+		mustCreate(t, conn, "airing_states", map[string]interface{}{
+			"anime_id": 1, "slot_id": 1, "air_date": "2026-10-03", "offset_minutes": 0,
+			"fired_at": now, "updated_at": now,
+		})
+	}
+}
+
+func mustCreate(t *testing.T, conn *gorm.DB, table string, row map[string]interface{}) {
+	t.Helper()
+	if err := conn.Table(table).Create(row).Error; err != nil {
+		t.Fatalf("insert into %s: %v", table, err)
+	}
+}
+
+func TestMigrateUpgradesEverySchemaVersion(t *testing.T) {
+	// Setiap versi yang pernah dirilis, termasuk database sebelum schema_migrations
+	for version := 0; version <= LatestSchemaVersion(); version++ {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			conn := openTestDB(t)
+			seedVersion(t, conn, version)
+
+			if err := Migrate(conn); err != nil {
+				t.Fatalf("Migrate: %v", err)
+			}
+			current, err := SchemaVersion(conn)
+			if err != nil {
+				t.Fatalf("SchemaVersion: %v", err)
+			}
+			if current != LatestSchemaVersion() {
+				t.Fatalf("schema version = %d, want %d", current, LatestSchemaVersion())
+			}
+			assertMigrated(t, conn, version)
+
+			var applied int64
+			conn.Model(&SchemaMigration{}).Count(&applied)
+
+			// Migrate kedua tidak boleh mengubah apa pun
+			if err := Migrate(conn); err != nil {
+				t.Fatalf("second Migrate: %v", err)
+			}
+			var reapplied int64
+			conn.Model(&SchemaMigration{}).Count(&reapplied)
+			if reapplied != applied {
+				t.Fatalf("second Migrate recorded %d migrations, want %d", reapplied, applied)
+			}
+			assertMigrated(t, conn, version)
+		})
+	}
+}
+
+// assertMigrated memastikan data yang di-seed di versi seededVersion masih utuh
+func assertMigrated(t *testing.T, conn *gorm.DB, seededVersion int) {
+	t.Helper()
+
+	var animes []models.Anime
+	if err := conn.Preload("Slots").Order("id").Find(&animes).Error; err != nil {
+		t.Fatalf("load animes: %v", err)
+	}
+	if len(animes) != len(fixtureAnimes) {
+		t.Fatalf("got %d animes, want %d", len(animes), len(fixtureAnimes))
+	}
+
+	for i, anime := range animes {
+		fixture := fixtureAnimes[i]
+		if anime.Title != fixture.title || anime.RingToneId != fixture.ringToneId {
+			t.Errorf("anime %d = %q ring tone %d, want %q ring tone %d",
+				i, anime.Title, anime.RingToneId, fixture.title, fixture.ringToneId)
+		}
+
+		// Kolom yang belum ada di versi lama berisi nilai kosong
+		wantTimezone, wantEpisodes, wantArchived, wantOffsets := "", 0, false, 0
+		if seededVersion >= 3 {
+			wantEpisodes, wantArchived = fixture.episodes, fixture.archived
+		}
+		if seededVersion >= 6 {
+			wantTimezone = fixture.timezone
+		}
+		if seededVersion >= 9 && fixture.offsets != "" {
+			wantOffsets = 1
+		}
+		if anime.Timezone != wantTimezone || anime.EpisodeCount != wantEpisodes || anime.Archived != wantArchived {
+			t.Errorf("%s timezone/episodes/archived = %q/%d/%v, want %q/%d/%v", anime.Title,
+				anime.Timezone, anime.EpisodeCount, anime.Archived, wantTimezone, wantEpisodes, wantArchived)
+		}
+		if hasPremiere := anime.PremiereDate != nil; hasPremiere != (wantEpisodes > 0) {
+			t.Errorf("%s premiere date = %v", anime.Title, anime.PremiereDate)
+		} else if hasPremiere && anime.PremiereDate.Format("2006-01-02") != "2026-10-03" {
+			t.Errorf("%s premiere date = %v, want 2026-10-03", anime.Title, anime.PremiereDate)
+		}
+		if len(anime.ReminderOffsets) != wantOffsets || (wantOffsets > 0 && anime.ReminderOffsets[0].Minutes != 15) {
+			t.Errorf("%s reminder offsets = %+v", anime.Title, anime.ReminderOffsets)
+		}
+
+		if len(anime.Slots) != 1 {
+			t.Errorf("%s has %d slots, want 1", anime.Title, len(anime.Slots))
+			continue
+		}
+		slot := anime.Slots[0]
+		// Migration 5 membuat slot yang aktif dari kolom day/time di animes
+		wantEnabled, wantLate := true, false
+		if seededVersion >= 5 {
+			wantEnabled = fixture.enabled
+		}
+		if seededVersion >= 7 {
+			wantLate = fixture.late
+		}
+		if slot.Day != fixture.weekday || slot.InvalidDay != fixture.invalidDay {
+			t.Errorf("%s slot day = %v (invalid %q), want %v (invalid %q)",
+				anime.Title, slot.Day, slot.InvalidDay, fixture.weekday, fixture.invalidDay)
+		}
+		if got, want := slot.Time.Format("15:04"), fixture.clock.Format("15:04"); got != want {
+			t.Errorf("%s slot time = %s, want %s", anime.Title, got, want)
+		}
+		if slot.Enabled != wantEnabled || slot.LateNight != wantLate {
+			t.Errorf("%s slot enabled/late night = %v/%v, want %v/%v",
+				anime.Title, slot.Enabled, slot.LateNight, wantEnabled, wantLate)
+		}
+	}
+
+	var ringTones []models.RingTone
+	conn.Find(&ringTones)
+	if len(ringTones) != 1 || ringTones[0].SongPath != "uploads/op.mp3" {
+		t.Errorf("ring tones = %+v", ringTones)
+	}
+
+	var episodes []models.Episode
+	conn.Find(&episodes)
+	if wantEpisodes := btoi(seededVersion >= 2); len(episodes) != wantEpisodes {
+		t.Errorf("got %d episodes, want %d", len(episodes), wantEpisodes)
+	} else if wantEpisodes > 0 && (!episodes[0].Watched || episodes[0].AnimeId != 1) {
+		t.Errorf("episode = %+v", episodes[0])
+	}
+
+	var events []models.ReminderEvent
+	conn.Preload("Deliveries").Find(&events)
+	if wantEvents := btoi(seededVersion >= 4); len(events) != wantEvents {
+		t.Fatalf("got %d reminder events, want %d", len(events), wantEvents)
+	}
+	if len(events) > 0 {
+		event := events[0]
+		wantSlot, wantOffset, wantAirDate := uint(0), 0, ""
+		if seededVersion >= 5 {
+			wantSlot = 1
+		}
+		if seededVersion >= 9 {
+			wantOffset = 15
+		}
+		if seededVersion >= 11 {
+			wantAirDate = "2026-10-03"
+		}
+		if event.AnimeTitle != "Frieren" || event.SlotId != wantSlot || event.OffsetMinutes != wantOffset || event.AirDate != wantAirDate {
+			t.Errorf("reminder event = %+v, want slot %d offset %d air date %q", event, wantSlot, wantOffset, wantAirDate)
+		}
+		if len(event.Deliveries) != 1 || event.Deliveries[0].Channel != "dbus" || !event.Deliveries[0].Success {
+			t.Errorf("deliveries = %+v", event.Deliveries)
+		}
+	}
+
+	var snoozes []models.Snooze
+	conn.Find(&snoozes)
+	if wantSnoozes := btoi(seededVersion >= 10); len(snoozes) != wantSnoozes {
+		t.Errorf("got %d snoozes, want %d", len(snoozes), wantSnoozes)
+	} else if wantSnoozes > 0 && (snoozes[0].EventId != 1 || snoozes[0].SlotId != 1) {
+		t.Errorf("snooze = %+v", snoozes[0])
+	}
+
+	var states []models.AiringState
+	conn.Find(&states)
+	if wantStates := btoi(seededVersion >= 11); len(states) != wantStates {
+		t.Errorf("got %d airing states, want %d", len(states), wantStates)
+	} else if wantStates > 0 && (states[0].AirDate != "2026-10-03" || states[0].FiredAt == nil) {
+		t.Errorf("airing state = %+v", states[0])
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestMigrateRejectsNewerDatabase(t *testing.T) {
+	conn := openTestDB(t)
+	if err := Migrate(conn); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	conn.Create(&SchemaMigration{Version: LatestSchemaVersion() + 1, Name: "future", AppliedAt: time.Now()})
+
+	if err := Migrate(conn); !errors.Is(err, ErrDatabaseTooNew) {
+		t.Fatalf("Migrate error = %v, want ErrDatabaseTooNew", err)
+	}
+}
+
+func TestMigrateKeepsUnknownDayName(t *testing.T) {
+	conn := openTestDB(t)
+	mustMigrateTo(t, conn, 7)
+	now := time.Now()
+	mustExec(t, conn, `INSERT INTO animes (title, image_path, ring_tone_id, episode_count, archived, timezone, created_at, updated_at)
+		VALUES ('Frieren', '', 0, 0, false, '', ?, ?)`, now, now)
+	mustExec(t, conn, `INSERT INTO schedule_slots (anime_id, label, day, time, ring_tone_id, enabled, late_night, created_at, updated_at)
+		VALUES (1, 'TV', 'Jum''at', ?, 0, true, false, ?, ?)`, clockAt(23, 0), now, now)
+
+	if err := Migrate(conn); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	var slot models.ScheduleSlot
+	if err := conn.First(&slot).Error; err != nil {
+		t.Fatalf("load slot: %v", err)
+	}
+	if slot.Day.Valid() {
+		t.Errorf("slot day = %v, want invalid", slot.Day)
+	}
+	if slot.InvalidDay != "Jum'at" {
+		t.Errorf("invalid day = %q, want %q", slot.InvalidDay, "Jum'at")
+	}
+	if got := slot.Time.Format("15:04"); got != "23:00" {
+		t.Errorf("slot time = %s, want 23:00", got)
+	}
+}
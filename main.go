@@ -1,11 +1,19 @@
 package main
 
 import (
+	"anime-reminder/controllers"
 	"anime-reminder/database"
+	"anime-reminder/models"
 	"anime-reminder/scheduler"
+	"anime-reminder/settings"
+	"anime-reminder/store"
 	"anime-reminder/ui"
 	"anime-reminder/utils"
+	"flag"
+	"fmt"
 	"log"
+	"time"
+	_ "time/tzdata" // database zona waktu untuk Windows / sistem tanpa tzdata
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -13,9 +21,41 @@ import (
 )
 
 func main() {
+	dataDirFlag := flag.String("data-dir", "", "directory for the database and uploads (default $"+utils.DataDirEnv+" or $XDG_DATA_HOME/"+utils.DataDirName+")")
+	storageFlag := flag.String("storage", "sqlite", "library storage backend: sqlite, json or memory")
+	flag.Parse()
+
 	// Create Fyne app instance
 	myApp := app.New()
 
+	// Resolve data directory (flag > env > XDG)
+	dataDir, err := utils.ResolveDataDir(*dataDirFlag)
+	if err != nil {
+		log.Fatalf("Failed to resolve data directory: %v", err)
+	}
+	if err := utils.SetDataDir(dataDir); err != nil {
+		log.Fatalf("Failed to initialize data directory: %v", err)
+	}
+	log.Printf("📁 Data directory: %s", dataDir)
+
+	// Pindahkan database & uploads lama dari working directory (sekali saja)
+	if err := utils.MigrateLegacyData(dataDir); err != nil {
+		log.Printf("⚠️ Failed to migrate legacy data: %v", err)
+	}
+
+	// Pengaturan aplikasi (bahasa nama hari, dll)
+	if err := settings.Load(utils.DataPath(settings.FileName)); err != nil {
+		log.Printf("⚠️ Failed to load settings, using defaults: %v", err)
+	}
+	dayLocale := settings.Get().DayLocale
+	if !models.SetDayLocale(dayLocale) {
+		models.SetDayLocale(models.DetectDayLocale())
+	}
+	// Volume ringtone mengikuti quiet hours
+	utils.SetVolumeFunc(func() int {
+		return settings.Get().VolumeAt(time.Now())
+	})
+
 	// Initialize database (GORM + SQLite)
 	if err := database.InitDB(); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -26,17 +66,25 @@ func main() {
 		log.Fatalf("Failed to initialize upload directories: %v", err)
 	}
 
+	// Pilih storage backend untuk library (anime & ringtone)
+	libraryStore, err := openStore(*storageFlag)
+	if err != nil {
+		log.Fatalf("Failed to open %s storage: %v", *storageFlag, err)
+	}
+	ctrl := controllers.NewControllers(libraryStore)
+
 	// Start anime reminder scheduler in background
 	stopCh := make(chan bool)
-	go scheduler.Scheduler(stopCh)
+	go scheduler.Scheduler(stopCh, ctrl)
 	log.Println("✅ Anime reminder scheduler started")
 
 	// Create main window
-	mainWindow := ui.NewMainWindow(myApp)
+	mainWindow := ui.NewMainWindow(myApp, ctrl)
+	mainWindow.ListenForReminders(myApp)
 
 	// Setup system tray (jika tersedia)
 	if desk, ok := myApp.(desktop.App); ok {
-		menu := setupSystemTray(myApp, mainWindow)
+		menu := setupSystemTray(myApp, mainWindow, ctrl)
 		desk.SetSystemTrayMenu(menu)
 		log.Println("✅ System tray initialized")
 	}
@@ -59,7 +107,24 @@ func main() {
 	mainWindow.ShowAndRun()
 }
 
-func setupSystemTray(myApp fyne.App, mainWindow *ui.MainWindow) *fyne.Menu {
+// openStore membuat storage backend sesuai flag --storage
+func openStore(kind string) (store.Store, error) {
+	switch kind {
+	case "sqlite":
+		return store.NewGormStore(database.GetDB()), nil
+	case "json":
+		path := utils.DataPath("library.json")
+		log.Printf("📄 Using JSON library: %s", path)
+		return store.NewJSONFileStore(path)
+	case "memory":
+		log.Println("⚠️ Using in-memory storage, data will be lost on exit")
+		return store.NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", kind)
+	}
+}
+
+func setupSystemTray(myApp fyne.App, mainWindow *ui.MainWindow, ctrl *controllers.Controllers) *fyne.Menu {
 	appName := "AnimeReminder"
 
 	// Menu item untuk toggle auto-start
@@ -94,7 +159,45 @@ func setupSystemTray(myApp fyne.App, mainWindow *ui.MainWindow) *fyne.Menu {
 		updateAutoStartText()
 	}
 
-	return fyne.NewMenu("Anime Reminder",
+	// Snooze / dismiss reminder terakhir yang masih aktif
+	snoozeItem := fyne.NewMenuItem("Snooze Reminder", nil)
+	snoozeItem.ChildMenu = fyne.NewMenu("")
+	for _, d := range models.SnoozeDurations {
+		d := d
+		snoozeItem.ChildMenu.Items = append(snoozeItem.ChildMenu.Items, fyne.NewMenuItem(fmt.Sprintf("%d minutes", int(d/time.Minute)), func() {
+			event, ok := scheduler.ActiveReminder()
+			if !ok {
+				log.Println("💤 No active reminder to snooze")
+				return
+			}
+			if _, err := scheduler.SnoozeReminder(ctrl, event.Id, d); err != nil {
+				log.Printf("❌ Failed to snooze reminder: %v", err)
+			}
+		}))
+	}
+	dismissItem := fyne.NewMenuItem("Dismiss Reminder", func() {
+		event, ok := scheduler.ActiveReminder()
+		if !ok {
+			return
+		}
+		if err := scheduler.DismissReminder(ctrl, event.Id); err != nil {
+			log.Printf("❌ Failed to dismiss reminder: %v", err)
+		}
+	})
+
+	// Status quiet hours, hanya informasi (tidak bisa diklik)
+	quietItem := fyne.NewMenuItem("", nil)
+	quietItem.Disabled = true
+	updateQuietText := func() {
+		if w, ok := settings.Get().QuietWindowAt(time.Now()); ok {
+			quietItem.Label = fmt.Sprintf("🌙 Quiet hours until %s (%s)", w.End, w.Mode.Label())
+		} else {
+			quietItem.Label = "Quiet hours off"
+		}
+	}
+	updateQuietText()
+
+	menu := fyne.NewMenu("Anime Reminder",
 		fyne.NewMenuItem("Show", func() {
 			mainWindow.Show()
 			log.Println("🔼 Application restored from tray")
@@ -104,10 +207,28 @@ func setupSystemTray(myApp fyne.App, mainWindow *ui.MainWindow) *fyne.Menu {
 			log.Println("🔽 Application hidden to tray")
 		}),
 		fyne.NewMenuItemSeparator(),
+		quietItem,
+		snoozeItem,
+		dismissItem,
+		fyne.NewMenuItemSeparator(),
 		autoStartItem,
 		fyne.NewMenuItemSeparator(),
 		fyne.NewMenuItem("Quit", func() {
 			myApp.Quit()
 		}),
 	)
+
+	// Perbarui status quiet hours setiap menit
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			fyne.Do(func() {
+				updateQuietText()
+				menu.Refresh()
+			})
+		}
+	}()
+
+	return menu
 }
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CommonTimezones adalah zona waktu yang ditawarkan di form, user tetap bisa mengetik zona lain
+var CommonTimezones = []string{
+	"Asia/Tokyo",
+	"Asia/Seoul",
+	"Asia/Shanghai",
+	"Asia/Jakarta",
+	"America/Los_Angeles",
+	"America/New_York",
+	"Europe/London",
+	"UTC",
+}
+
+// LoadTimezone memvalidasi nama zona waktu. String kosong berarti waktu lokal.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+// Location returns the broadcast timezone, falling back to local time
+func (a Anime) Location() *time.Location {
+	loc, err := LoadTimezone(a.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// SlotAiringsBetween mengembalikan semua waktu tayang slot di rentang [from, to).
+// Hari dan jam slot dihitung di zona siaran, jadi hasilnya sudah benar untuk
+// hari lokal yang bergeser maupun pergantian DST.
+func (a Anime) SlotAiringsBetween(slot ScheduleSlot, from, to time.Time) []time.Time {
+	if !slot.Day.Valid() || !to.After(from) {
+		return nil
+	}
+
+	loc := a.Location()
+	start := from.In(loc)
+	end := to.In(loc)
+
+	var airings []time.Time
+	// Mulai sehari sebelumnya supaya jam 24+ / pergeseran zona tidak terlewat
+	day := time.Date(start.Year(), start.Month(), start.Day()-1, 0, 0, 0, 0, loc)
+	for !day.After(end) {
+		if WeekdayOf(day) == slot.Day {
+			airAt := time.Date(day.Year(), day.Month(), day.Day(), slot.Time.Hour(), slot.Time.Minute(), 0, 0, loc)
+			if !airAt.Before(from) && airAt.Before(to) {
+				airings = append(airings, airAt)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return airings
+}
+
+// NextSlotAiring returns the first airing of slot at or after from
+func (a Anime) NextSlotAiring(slot ScheduleSlot, from time.Time) (time.Time, bool) {
+	airings := a.SlotAiringsBetween(slot, from, from.AddDate(0, 0, 8))
+	if len(airings) == 0 {
+		return time.Time{}, false
+	}
+	return airings[0], true
+}
+
+// SlotAiring adalah satu tayangan slot pada waktu tertentu
+type SlotAiring struct {
+	Anime Anime
+	Slot  ScheduleSlot
+	At    time.Time
+}
+
+// AiringsBetween mengembalikan tayangan semua slot aktif di rentang [from, to), urut waktu
+func (a Anime) AiringsBetween(from, to time.Time) []SlotAiring {
+	var airings []SlotAiring
+	for _, slot := range a.Slots {
+		if !slot.Enabled {
+			continue
+		}
+		for _, at := range a.SlotAiringsBetween(slot, from, to) {
+			airings = append(airings, SlotAiring{Anime: a, Slot: slot, At: at})
+		}
+	}
+	sort.SliceStable(airings, func(i, j int) bool { return airings[i].At.Before(airings[j].At) })
+	return airings
+}
+
+// SlotSummary menampilkan jadwal slot di zona siaran, ditambah waktu lokal jika berbeda,
+// misalnya "Sabtu at 01:00 (TV) JST → Jumat 18:00 local"
+func (a Anime) SlotSummary(slot ScheduleSlot, now time.Time) string {
+	text := slot.Summary()
+	if a.Timezone == "" {
+		return text
+	}
+
+	next, ok := a.NextSlotAiring(slot, now)
+	if !ok {
+		return text
+	}
+
+	source := next.In(a.Location())
+	local := next.In(time.Local)
+	text += " " + source.Format("MST")
+	if source.Format("Mon 15:04") != local.Format("Mon 15:04") {
+		text += fmt.Sprintf(" → %s %s local", WeekdayOf(local), local.Format("15:04"))
+	}
+	return text
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// AiringState menyimpan status satu reminder untuk satu tayangan
+// (anime + slot + tanggal tayang + offset), supaya reminder tidak dikirim dua kali
+// walaupun aplikasi di-restart. Snooze tidak dicatat di sini, lihat Snooze.
+type AiringState struct {
+	Id      uint `gorm:"primary_key;auto_increment"`
+	AnimeId uint `gorm:"uniqueIndex:idx_airing_state"`
+	SlotId  uint `gorm:"uniqueIndex:idx_airing_state"`
+	// AirDate adalah tanggal tayang di zona siaran (YYYY-MM-DD), lihat AiringDate
+	AirDate string `gorm:"size:10;uniqueIndex:idx_airing_state"`
+	// OffsetMinutes 0 untuk reminder saat tayang, > 0 untuk advance reminder
+	OffsetMinutes  int `gorm:"uniqueIndex:idx_airing_state"`
+	FiredAt        *time.Time
+	AcknowledgedAt *time.Time
+	UpdatedAt      time.Time
+}
+
+// Handled returns true if the reminder was already fired or acknowledged
+func (s AiringState) Handled() bool {
+	return s.FiredAt != nil || s.AcknowledgedAt != nil
+}
+
+// AiringDate returns the broadcast date of an airing in the anime timezone
+func (a Anime) AiringDate(at time.Time) string {
+	return at.In(a.Location()).Format("2006-01-02")
+}
@@ -1,19 +1,216 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Anime struct {
-	Id         uint   `gorm:"primary_key;auto_increment"`
-	Title      string `gorm:"size:255"`
-	Day        string `gorm:"size:50"`
-	Time       time.Time
-	ImagePath  string `gorm:"size:500"`
+	Id        uint   `gorm:"primary_key;auto_increment"`
+	Title     string `gorm:"size:255"`
+	ImagePath string `gorm:"size:500"`
+	// StreamURL adalah link nonton (opsional), dibuka saat notifikasi di HP diklik
+	StreamURL string `gorm:"size:500"`
+	// Timezone adalah zona waktu siaran (IANA, misalnya "Asia/Tokyo").
+	// Day dan Time di setiap slot dibaca dalam zona ini, kosong berarti waktu lokal.
+	Timezone string `gorm:"size:64"`
+	// RingToneId adalah ringtone default, dipakai slot yang tidak punya ringtone sendiri
 	RingToneId uint
+	Season     `gorm:"embedded"`
+	Archived   bool
+	Slots      []ScheduleSlot `gorm:"foreignKey:AnimeId"`
+	// ReminderOffsets adalah advance reminder anime ini, kosong berarti pakai default global
+	ReminderOffsets []ReminderOffset `gorm:"serializer:json"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ScheduleSlot adalah satu jadwal tayang mingguan dari sebuah anime,
+// misalnya siaran TV dan rilis streaming di hari yang berbeda
+type ScheduleSlot struct {
+	Id      uint   `gorm:"primary_key;auto_increment"`
+	AnimeId uint   `gorm:"index"`
+	Label   string `gorm:"size:50"`
+	// Day disimpan sebagai integer (lihat Weekday), nama hari hanya untuk tampilan
+	Day Weekday
+	// InvalidDay berisi nama hari lama yang tidak dikenali saat migrasi (Day = -1).
+	// Slot seperti ini tidak pernah di-fire sampai user memilih hari di slot editor.
+	InvalidDay string `gorm:"size:50"`
+	// Time hanya dipakai jam dan menitnya, dalam zona Anime.Timezone.
+	// Day dan Time selalu disimpan sebagai hari & jam sebenarnya (00-23).
+	Time time.Time
+	// LateNight true jika jadwal aslinya ditulis dengan notasi 24+ jam,
+	// misalnya "Sabtu 25:30" disimpan sebagai Minggu 01:30
+	LateNight bool
+	// RingToneId 0 berarti pakai ringtone default anime
+	RingToneId uint
+	Enabled    bool
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 }
 
-// Gunakan kapitalisasi konsisten
-var Days = []string{
-	"Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu", "Minggu",
+// MaxLateNightHour adalah jam terbesar yang diterima untuk notasi 24+ (29:59 = 05:59 hari berikutnya)
+const MaxLateNightHour = 29
+
+// NewScheduleSlot membuat slot dari input user. Jam 24-29 dengan hari penyiar
+// (notasi TV Jepang) digulung ke hari dan jam sebenarnya.
+func NewScheduleSlot(label string, day Weekday, hour, minute int) (ScheduleSlot, error) {
+	if !day.Valid() {
+		return ScheduleSlot{}, fmt.Errorf("invalid day")
+	}
+	if hour < 0 || hour > MaxLateNightHour || minute < 0 || minute > 59 {
+		return ScheduleSlot{}, fmt.Errorf("invalid time %02d:%02d (hour must be 00-%d)", hour, minute, MaxLateNightHour)
+	}
+
+	slot := ScheduleSlot{Label: label, Day: day}
+	if hour >= 24 {
+		slot.Day = day.Next()
+		hour -= 24
+		slot.LateNight = true
+	}
+	slot.Time = time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+	return slot, nil
+}
+
+// BroadcastNotation returns the day and hour as written by the broadcaster
+// (e.g. Sabtu 25 for a late-night slot stored as Minggu 01)
+func (s ScheduleSlot) BroadcastNotation() (day Weekday, hour, minute int) {
+	day, hour, minute = s.Day, s.Time.Hour(), s.Time.Minute()
+	if s.LateNight {
+		day = s.Day.Prev()
+		hour += 24
+	}
+	return day, hour, minute
+}
+
+// Summary menghasilkan teks seperti "Senin at 23:00 (TV)", atau untuk slot
+// late-night "Sabtu at 25:30 = Minggu 01:30 (TV)"
+func (s ScheduleSlot) Summary() string {
+	text := fmt.Sprintf("%s at %s", s.Day, s.Time.Format("15:04"))
+	if !s.Day.Valid() {
+		text = fmt.Sprintf("invalid day %q at %s", s.InvalidDay, s.Time.Format("15:04"))
+	} else if s.LateNight {
+		day, hour, minute := s.BroadcastNotation()
+		text = fmt.Sprintf("%s at %02d:%02d = %s %s", day, hour, minute, s.Day, s.Time.Format("15:04"))
+	}
+	if s.Label != "" {
+		text += fmt.Sprintf(" (%s)", s.Label)
+	}
+	return text
+}
+
+// SlotLabels adalah label yang disarankan di form, user tetap bisa mengisi label lain
+var SlotLabels = []string{"TV", "Crunchyroll", "Netflix", "Dub"}
+
+// Season menyimpan batas musim tayang. Semua field opsional:
+// tanpa PremiereDate anime dianggap sudah tayang, tanpa EpisodeCount/EndDate tidak pernah selesai.
+type Season struct {
+	PremiereDate *time.Time
+	EpisodeCount int
+	EndDate      *time.Time
+}
+
+// DateKey mengembalikan tanggal lokal dalam format YYYY-MM-DD, dipakai untuk membandingkan hari
+func DateKey(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02")
+}
+
+// seasonDay mengubah tanggal musim (diisi user sebagai tanggal siaran, disimpan sebagai
+// tengah malam lokal) menjadi awal hari yang sama di zona siaran
+func (a Anime) seasonDay(date time.Time) time.Time {
+	local := date.In(time.Local)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, a.Location())
+}
+
+// HasPremiered returns true if the broadcast date of at is on or after the premiere date
+func (a Anime) HasPremiered(at time.Time) bool {
+	if a.PremiereDate == nil {
+		return true
+	}
+	return a.AiringDate(at) >= a.AiringDate(a.seasonDay(*a.PremiereDate))
+}
+
+// IsPremiereDay returns true if the broadcast date of at is the premiere date
+func (a Anime) IsPremiereDay(at time.Time) bool {
+	return a.PremiereDate != nil && a.AiringDate(at) == a.AiringDate(a.seasonDay(*a.PremiereDate))
+}
+
+// IsFinaleDay returns true if the broadcast date of at is the day the finale airs
+func (a Anime) IsFinaleDay(at time.Time) bool {
+	finaleAirAt, ok := a.FinaleAirAt()
+	return ok && a.AiringDate(at) == a.AiringDate(finaleAirAt)
+}
+
+// PrimarySlot returns the first enabled slot (or the first slot if none is enabled).
+// Jadwal episode dan finale dihitung dari slot ini.
+func (a Anime) PrimarySlot() (ScheduleSlot, bool) {
+	for _, slot := range a.Slots {
+		if slot.Enabled {
+			return slot, true
+		}
+	}
+	if len(a.Slots) > 0 {
+		return a.Slots[0], true
+	}
+	return ScheduleSlot{}, false
+}
+
+// SlotRingToneId returns the slot ringtone, falling back to the anime default
+func (a Anime) SlotRingToneId(slot ScheduleSlot) uint {
+	if slot.RingToneId > 0 {
+		return slot.RingToneId
+	}
+	return a.RingToneId
+}
+
+// FinaleAirAt returns the finale broadcast date combined with the latest enabled slot
+// airing on that weekday (or the primary slot time), in the broadcast timezone.
+// Tanpa EndDate, finale dihitung seperti EpisodeController.GenerateEpisodes:
+// tayangan pertama slot utama mulai PremiereDate, ditambah EpisodeCount-1 minggu.
+func (a Anime) FinaleAirAt() (time.Time, bool) {
+	primary, ok := a.PrimarySlot()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	var finale time.Time
+	switch {
+	case a.EndDate != nil:
+		finale = a.seasonDay(*a.EndDate)
+	case a.PremiereDate != nil && a.EpisodeCount > 0:
+		first := a.seasonDay(*a.PremiereDate)
+		for primary.Day.Valid() && WeekdayOf(first) != primary.Day {
+			first = first.AddDate(0, 0, 1)
+		}
+		finale = first.AddDate(0, 0, 7*(a.EpisodeCount-1))
+	default:
+		return time.Time{}, false
+	}
+
+	loc := a.Location()
+	airAt := time.Date(finale.Year(), finale.Month(), finale.Day(), primary.Time.Hour(), primary.Time.Minute(), 0, 0, loc)
+
+	for _, slot := range a.Slots {
+		if !slot.Enabled || slot.Day != WeekdayOf(finale) {
+			continue
+		}
+		slotAt := time.Date(finale.Year(), finale.Month(), finale.Day(), slot.Time.Hour(), slot.Time.Minute(), 0, 0, loc)
+		if slotAt.After(airAt) {
+			airAt = slotAt
+		}
+	}
+	return airAt, true
+}
+
+// Clone returns a copy of the anime that does not share the Slots or ReminderOffsets slices
+func (a Anime) Clone() Anime {
+	a.Slots = append([]ScheduleSlot(nil), a.Slots...)
+	a.ReminderOffsets = append([]ReminderOffset(nil), a.ReminderOffsets...)
+	return a
+}
+
+// HasEnded returns true once the finale has aired
+func (a Anime) HasEnded(now time.Time) bool {
+	finaleAirAt, ok := a.FinaleAirAt()
+	return ok && now.After(finaleAirAt)
 }
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeasonDatesUseBroadcastTimezone(t *testing.T) {
+	local, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	originalLocal := time.Local
+	time.Local = local
+	defer func() { time.Local = originalLocal }()
+
+	// Tanggal musim diisi sebagai tanggal siaran di Jepang (Sabtu), tersimpan sebagai tengah malam lokal
+	premiere := time.Date(2026, 10, 3, 0, 0, 0, 0, time.Local)
+	slot, err := NewScheduleSlot("TV", Saturday, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slot.Enabled = true
+	anime := Anime{
+		Title:    "Frieren",
+		Timezone: "Asia/Tokyo",
+		Slots:    []ScheduleSlot{slot},
+		Season:   Season{PremiereDate: &premiere, EpisodeCount: 2},
+	}
+
+	// Sabtu 01:00 JST = Jumat 09:00 PDT
+	premiereAirAt := time.Date(2026, 10, 2, 9, 0, 0, 0, local)
+	finaleAirAt := time.Date(2026, 10, 9, 9, 0, 0, 0, local)
+
+	if !anime.HasPremiered(premiereAirAt) {
+		t.Error("HasPremiered is false when the first episode airs")
+	}
+	if anime.HasPremiered(premiereAirAt.Add(-2 * time.Hour)) {
+		t.Error("HasPremiered is true before the premiere broadcast date")
+	}
+	if !anime.IsPremiereDay(premiereAirAt) {
+		t.Error("IsPremiereDay is false for the first episode")
+	}
+
+	got, ok := anime.FinaleAirAt()
+	if !ok || !got.Equal(finaleAirAt) {
+		t.Errorf("FinaleAirAt = %v (ok %v), want %v", got.In(local), ok, finaleAirAt)
+	}
+	if !anime.IsFinaleDay(finaleAirAt) {
+		t.Error("IsFinaleDay is false for the last episode")
+	}
+	if anime.HasEnded(finaleAirAt.Add(-time.Minute)) {
+		t.Error("HasEnded is true before the finale airs")
+	}
+	if !anime.HasEnded(finaleAirAt.Add(time.Minute)) {
+		t.Error("HasEnded is false after the finale aired")
+	}
+
+	// EndDate juga tanggal siaran
+	end := time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local)
+	anime.Season = Season{PremiereDate: &premiere, EndDate: &end}
+	if got, ok := anime.FinaleAirAt(); !ok || !got.Equal(finaleAirAt) {
+		t.Errorf("FinaleAirAt with EndDate = %v (ok %v), want %v", got.In(local), ok, finaleAirAt)
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// DefaultDigestTemplate dipakai untuk satu baris digest jika settings tidak punya template sendiri
+const DefaultDigestTemplate = "{{.Time}} {{.Title}}{{if .Episode}} ({{.Episode}}){{end}}"
+
+// DefaultWeeklyDigestTime adalah jam digest mingguan hari Minggu jika belum diatur
+const DefaultWeeklyDigestTime = "20:00"
+
+// DigestLine adalah data yang tersedia di template satu baris digest
+type DigestLine struct {
+	Title   string // judul anime
+	Episode string // misalnya "Episode 7/12", kosong jika belum ada jadwal episode
+	Slot    string // label slot, misalnya "TV"
+	Day     string // nama hari lokal, misalnya "Senin"
+	Time    string // jam tayang lokal, misalnya "23:30"
+}
+
+// RenderDigestLine menghasilkan satu baris digest dari template (kosong = DefaultDigestTemplate)
+func RenderDigestLine(text string, line DigestLine) (string, error) {
+	if text == "" {
+		text = DefaultDigestTemplate
+	}
+
+	tmpl, err := template.New("digest").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, line); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ValidateDigestTemplate memastikan template digest bisa di-parse
+func ValidateDigestTemplate(text string) error {
+	if text == "" {
+		return nil
+	}
+	if _, err := template.New("digest").Parse(text); err != nil {
+		return fmt.Errorf("invalid digest template: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"strings"
+	"text/template"
+)
+
+// EmailSecurity menentukan cara koneksi ke server SMTP
+type EmailSecurity string
+
+const (
+	// EmailStartTLS terhubung biasa lalu upgrade dengan STARTTLS (biasanya port 587)
+	EmailStartTLS EmailSecurity = "starttls"
+	// EmailTLS memakai TLS sejak awal (implicit TLS, biasanya port 465)
+	EmailTLS EmailSecurity = "tls"
+	// EmailPlain tanpa enkripsi, hanya untuk relay lokal
+	EmailPlain EmailSecurity = "none"
+)
+
+// EmailSecurities adalah urutan pilihan di UI
+var EmailSecurities = []EmailSecurity{EmailStartTLS, EmailTLS, EmailPlain}
+
+// DefaultPort returns the usual SMTP port for the security mode
+func (s EmailSecurity) DefaultPort() int {
+	switch s {
+	case EmailTLS:
+		return 465
+	case EmailPlain:
+		return 25
+	default:
+		return 587
+	}
+}
+
+// EmailCoverCID adalah Content-ID cover anime yang dilampirkan inline
+const EmailCoverCID = "cover@anime-reminder"
+
+// DefaultEmailTextTemplate adalah isi plain-text email jika settings tidak punya template sendiri
+const DefaultEmailTextTemplate = `{{.Title}}
+
+{{.Body}}
+{{if .AirTime}}
+Airs at: {{.AirTime}}{{end}}
+
+--
+Anime Reminder
+`
+
+// DefaultEmailHTMLTemplate adalah isi HTML email (html/template, field otomatis di-escape)
+const DefaultEmailHTMLTemplate = `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #222;">
+  <table cellpadding="0" cellspacing="0"><tr>
+    {{if .Cover}}<td style="padding-right: 16px; vertical-align: top;"><img src="cid:{{.CoverCID}}" alt="" width="128" style="border-radius: 6px;"></td>{{end}}
+    <td style="vertical-align: top;">
+      <h2 style="margin: 0 0 8px 0;">{{.Title}}</h2>
+      {{range .Lines}}<p style="margin: 0 0 4px 0;">{{.}}</p>{{end}}
+      {{if .AirTime}}<p style="margin: 8px 0 0 0; color: #666;">Airs at {{.AirTime}}</p>{{end}}
+    </td>
+  </tr></table>
+</body>
+</html>
+`
+
+// Email adalah pengaturan pengiriman reminder lewat SMTP
+type Email struct {
+	Enabled  bool          `json:"enabled"`
+	Host     string        `json:"host"`
+	Port     int           `json:"port,omitempty"` // 0 = port default Security
+	Security EmailSecurity `json:"security,omitempty"`
+	// Username kosong berarti server tidak memerlukan login
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from"`
+	// To berisi satu atau lebih alamat dipisah koma
+	To string `json:"to"`
+	// Digest juga mengirim digest harian/mingguan lewat email
+	Digest bool `json:"digest,omitempty"`
+	// TextTemplate / HTMLTemplate kosong = template default
+	TextTemplate string `json:"text_template,omitempty"`
+	HTMLTemplate string `json:"html_template,omitempty"`
+}
+
+// EmailMessage adalah data yang tersedia di template email
+type EmailMessage struct {
+	Title      string
+	Body       string
+	Lines      []string // Body per baris, untuk HTML
+	AnimeTitle string
+	Episode    string
+	AirTime    string // jam tayang lokal, kosong jika tidak diketahui
+	Cover      bool   // true jika cover dilampirkan dengan Content-ID CoverCID
+	CoverCID   string
+}
+
+// SecurityMode returns Security, default EmailStartTLS
+func (e Email) SecurityMode() EmailSecurity {
+	if e.Security == "" {
+		return EmailStartTLS
+	}
+	return e.Security
+}
+
+// Address returns host:port of the SMTP server
+func (e Email) Address() string {
+	port := e.Port
+	if port <= 0 {
+		port = e.SecurityMode().DefaultPort()
+	}
+	return fmt.Sprintf("%s:%d", e.Host, port)
+}
+
+// Recipients returns the parsed To addresses
+func (e Email) Recipients() ([]string, error) {
+	list, err := mail.ParseAddressList(e.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient: %v", err)
+	}
+	addresses := make([]string, len(list))
+	for i, addr := range list {
+		addresses[i] = addr.Address
+	}
+	return addresses, nil
+}
+
+// Configured returns true if email is enabled and has a server, sender and recipient
+func (e Email) Configured() bool {
+	return e.Enabled && e.Host != "" && e.From != "" && e.To != ""
+}
+
+// Render menghasilkan isi plain-text dan HTML email untuk msg
+func (e Email) Render(msg EmailMessage) (text string, htmlBody string, err error) {
+	msg.Lines = strings.Split(strings.TrimSpace(msg.Body), "\n")
+	msg.CoverCID = EmailCoverCID
+
+	textSource := e.TextTemplate
+	if textSource == "" {
+		textSource = DefaultEmailTextTemplate
+	}
+	textTmpl, err := template.New("text").Parse(textSource)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid text template: %v", err)
+	}
+	var textBuf bytes.Buffer
+	if err := textTmpl.Execute(&textBuf, msg); err != nil {
+		return "", "", fmt.Errorf("invalid text template: %v", err)
+	}
+
+	htmlSource := e.HTMLTemplate
+	if htmlSource == "" {
+		htmlSource = DefaultEmailHTMLTemplate
+	}
+	htmlTmpl, err := htmltemplate.New("html").Parse(htmlSource)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid HTML template: %v", err)
+	}
+	var htmlBuf bytes.Buffer
+	if err := htmlTmpl.Execute(&htmlBuf, msg); err != nil {
+		return "", "", fmt.Errorf("invalid HTML template: %v", err)
+	}
+	return textBuf.String(), htmlBuf.String(), nil
+}
+
+// Validate memastikan server, alamat dan template email benar jika email aktif
+func (e Email) Validate() error {
+	if !e.Enabled {
+		return nil
+	}
+	if e.Host == "" {
+		return fmt.Errorf("please enter the SMTP server")
+	}
+	if e.Port < 0 || e.Port > 65535 {
+		return fmt.Errorf("invalid SMTP port %d", e.Port)
+	}
+	switch e.SecurityMode() {
+	case EmailStartTLS, EmailTLS, EmailPlain:
+	default:
+		return fmt.Errorf("unknown security mode %q", e.Security)
+	}
+	if _, err := mail.ParseAddress(e.From); err != nil {
+		return fmt.Errorf("invalid sender address: %v", err)
+	}
+	if _, err := e.Recipients(); err != nil {
+		return err
+	}
+	_, _, err := e.Render(EmailMessage{Title: "Sample", Body: "Sample\nreminder", AirTime: "2006-01-02 15:04", Cover: true})
+	return err
+}
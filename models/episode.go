@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Episode adalah satu episode dari sebuah anime, dengan jadwal tayang dan status tonton
+type Episode struct {
+	Id        uint `gorm:"primary_key;auto_increment"`
+	AnimeId   uint `gorm:"index"`
+	Number    int
+	Title     string `gorm:"size:255"`
+	AirAt     time.Time
+	Aired     bool
+	Watched   bool
+	WatchedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasAired returns true if the episode is flagged as aired or its air time has passed
+func (e Episode) HasAired(now time.Time) bool {
+	return e.Aired || !e.AirAt.After(now)
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PushPriorities memetakan urgency notifikasi ke prioritas server push.
+// 0 berarti pakai prioritas default backend.
+type PushPriorities struct {
+	Low      int `json:"low,omitempty"`
+	Normal   int `json:"normal,omitempty"`
+	Critical int `json:"critical,omitempty"`
+}
+
+// Default prioritas: ntfy memakai 1-5, Gotify 1-10
+var (
+	DefaultNtfyPriorities   = PushPriorities{Low: 2, Normal: 3, Critical: 5}
+	DefaultGotifyPriorities = PushPriorities{Low: 2, Normal: 5, Critical: 8}
+)
+
+// orDefault mengisi prioritas yang kosong dengan def
+func (p PushPriorities) orDefault(def PushPriorities) PushPriorities {
+	if p.Low == 0 {
+		p.Low = def.Low
+	}
+	if p.Normal == 0 {
+		p.Normal = def.Normal
+	}
+	if p.Critical == 0 {
+		p.Critical = def.Critical
+	}
+	return p
+}
+
+func (p PushPriorities) validate(min, max int) error {
+	for _, value := range []int{p.Low, p.Normal, p.Critical} {
+		if value != 0 && (value < min || value > max) {
+			return fmt.Errorf("priority must be between %d and %d", min, max)
+		}
+	}
+	return nil
+}
+
+// Ntfy adalah pengaturan push ke satu topic ntfy
+type Ntfy struct {
+	Enabled bool `json:"enabled"`
+	// ServerURL wajib diisi, misalnya https://ntfy.sh atau server sendiri
+	ServerURL string `json:"server_url"`
+	Topic     string `json:"topic"`
+	// Token adalah access token untuk topic yang dilindungi (opsional)
+	Token      string         `json:"token,omitempty"`
+	Priorities PushPriorities `json:"priorities,omitempty"`
+	// Tags adalah tag/emoji shortcode tambahan untuk setiap pesan, misalnya "tv"
+	Tags []string `json:"tags,omitempty"`
+	// ClickURL dibuka jika anime tidak punya stream link (opsional)
+	ClickURL string `json:"click_url,omitempty"`
+}
+
+// Server returns ServerURL without trailing slash
+func (n Ntfy) Server() string {
+	return strings.TrimRight(n.ServerURL, "/")
+}
+
+// PriorityMap returns the priorities with defaults filled in
+func (n Ntfy) PriorityMap() PushPriorities {
+	return n.Priorities.orDefault(DefaultNtfyPriorities)
+}
+
+// Configured returns true if ntfy is enabled and has a server and topic
+func (n Ntfy) Configured() bool {
+	return n.Enabled && n.ServerURL != "" && n.Topic != ""
+}
+
+// Validate memastikan server, topic dan prioritas benar jika ntfy aktif
+func (n Ntfy) Validate() error {
+	if !n.Enabled {
+		return nil
+	}
+	if n.ServerURL == "" {
+		return fmt.Errorf("please enter the ntfy server URL")
+	}
+	if n.Topic == "" {
+		return fmt.Errorf("please enter the ntfy topic")
+	}
+	if err := validateHTTPURL("ntfy server", n.ServerURL); err != nil {
+		return err
+	}
+	if err := validateHTTPURL("ntfy click URL", n.ClickURL); err != nil {
+		return err
+	}
+	if err := n.Priorities.validate(1, 5); err != nil {
+		return fmt.Errorf("ntfy %v", err)
+	}
+	return nil
+}
+
+// Gotify adalah pengaturan push ke server Gotify
+type Gotify struct {
+	Enabled   bool   `json:"enabled"`
+	ServerURL string `json:"server_url"`
+	// AppToken adalah token aplikasi dari halaman Apps di Gotify
+	AppToken   string         `json:"app_token"`
+	Priorities PushPriorities `json:"priorities,omitempty"`
+	// Tags adalah emoji shortcode tambahan yang ditampilkan di depan judul
+	Tags []string `json:"tags,omitempty"`
+	// ClickURL dibuka jika anime tidak punya stream link (opsional)
+	ClickURL string `json:"click_url,omitempty"`
+}
+
+// Server returns ServerURL without trailing slash
+func (g Gotify) Server() string {
+	return strings.TrimRight(g.ServerURL, "/")
+}
+
+// PriorityMap returns the priorities with defaults filled in
+func (g Gotify) PriorityMap() PushPriorities {
+	return g.Priorities.orDefault(DefaultGotifyPriorities)
+}
+
+// Configured returns true if Gotify is enabled and has a server and token
+func (g Gotify) Configured() bool {
+	return g.Enabled && g.ServerURL != "" && g.AppToken != ""
+}
+
+// Validate memastikan server, token dan prioritas benar jika Gotify aktif
+func (g Gotify) Validate() error {
+	if !g.Enabled {
+		return nil
+	}
+	if g.ServerURL == "" {
+		return fmt.Errorf("please enter the Gotify server URL")
+	}
+	if err := validateHTTPURL("Gotify server", g.ServerURL); err != nil {
+		return err
+	}
+	if g.AppToken == "" {
+		return fmt.Errorf("please enter the Gotify app token")
+	}
+	if err := validateHTTPURL("Gotify click URL", g.ClickURL); err != nil {
+		return err
+	}
+	if err := g.Priorities.validate(1, 10); err != nil {
+		return fmt.Errorf("Gotify %v", err)
+	}
+	return nil
+}
+
+// ParseTags membaca daftar tag dipisah koma, misalnya "tv, anime"
+func ParseTags(text string) []string {
+	var tags []string
+	for _, tag := range strings.Split(text, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// validateHTTPURL: kosong boleh, selain itu harus http(s)
+func validateHTTPURL(name, value string) error {
+	if value == "" || strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return nil
+	}
+	return fmt.Errorf("%s must start with http:// or https://", name)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestPushValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  interface{ Validate() error }
+		wantErr bool
+	}{
+		{name: "ntfy disabled without server", config: Ntfy{Topic: "anime"}},
+		{name: "ntfy without server", config: Ntfy{Enabled: true, Topic: "anime"}, wantErr: true},
+		{name: "ntfy without topic", config: Ntfy{Enabled: true, ServerURL: "https://ntfy.example.com"}, wantErr: true},
+		{name: "ntfy valid", config: Ntfy{Enabled: true, ServerURL: "https://ntfy.example.com", Topic: "anime"}},
+		{name: "ntfy priority above 5", config: Ntfy{Enabled: true, ServerURL: "https://ntfy.example.com", Topic: "anime", Priorities: PushPriorities{Critical: 6}}, wantErr: true},
+		{name: "gotify default priorities", config: Gotify{Enabled: true, ServerURL: "https://gotify.example.com", AppToken: "AbCdEf", Priorities: DefaultGotifyPriorities}},
+		{name: "gotify priority 10", config: Gotify{Enabled: true, ServerURL: "https://gotify.example.com", AppToken: "AbCdEf", Priorities: PushPriorities{Critical: 10}}},
+		{name: "gotify priority below 1", config: Gotify{Enabled: true, ServerURL: "https://gotify.example.com", AppToken: "AbCdEf", Priorities: PushPriorities{Low: -1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNtfyConfiguredNeedsServer(t *testing.T) {
+	// Tidak ada server default, tanpa server ntfy tidak dipakai
+	if (Ntfy{Enabled: true, Topic: "anime"}).Configured() {
+		t.Error("ntfy without a server URL is configured")
+	}
+	n := Ntfy{Enabled: true, ServerURL: "https://ntfy.example.com/", Topic: "anime"}
+	if !n.Configured() || n.Server() != "https://ntfy.example.com" {
+		t.Errorf("Configured() = %v, Server() = %q", n.Configured(), n.Server())
+	}
+}
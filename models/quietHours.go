@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietMode menentukan apa yang terjadi pada reminder selama quiet hours
+type QuietMode string
+
+const (
+	// QuietMuteSound tetap mengirim notifikasi, tapi tanpa ringtone
+	QuietMuteSound QuietMode = "mute_sound"
+	// QuietSilent tidak mengirim notifikasi maupun ringtone, reminder hanya dicatat di history
+	QuietSilent QuietMode = "silent"
+	// QuietReducedVolume memutar ringtone dengan volume QuietWindow.Volume
+	QuietReducedVolume QuietMode = "reduced_volume"
+)
+
+// QuietModes adalah urutan mode di UI, dari yang paling ringan
+var QuietModes = []QuietMode{QuietReducedVolume, QuietMuteSound, QuietSilent}
+
+// DefaultQuietVolume dipakai QuietReducedVolume jika Volume belum diatur
+const DefaultQuietVolume = 30
+
+// Label returns a short description of the mode for the UI
+func (m QuietMode) Label() string {
+	switch m {
+	case QuietMuteSound:
+		return "Mute sound"
+	case QuietSilent:
+		return "Suppress everything"
+	case QuietReducedVolume:
+		return "Reduced volume"
+	default:
+		return string(m)
+	}
+}
+
+// rank dipakai memilih window paling ketat jika beberapa window aktif bersamaan
+func (m QuietMode) rank() int {
+	for i, mode := range QuietModes {
+		if mode == m {
+			return i
+		}
+	}
+	return -1
+}
+
+// QuietWindow adalah satu rentang quiet hours yang dimulai pada Day.
+// End <= Start berarti window lewat tengah malam (misalnya Jumat 23:00 - 07:00).
+type QuietWindow struct {
+	Day   Weekday   `json:"day"`
+	Start string    `json:"start"` // "HH:MM" waktu lokal
+	End   string    `json:"end"`   // "HH:MM" waktu lokal
+	Mode  QuietMode `json:"mode"`
+	// Volume dalam persen (1-100), hanya untuk QuietReducedVolume. 0 = DefaultQuietVolume.
+	Volume int `json:"volume,omitempty"`
+}
+
+// ParseClock mengubah "HH:MM" menjadi menit sejak tengah malam
+func ParseClock(text string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(text, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", text)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", text)
+	}
+	return hour*60 + minute, nil
+}
+
+// Validate checks the day, times, mode and volume of the window
+func (w QuietWindow) Validate() error {
+	if !w.Day.Valid() {
+		return fmt.Errorf("invalid day")
+	}
+	start, err := ParseClock(w.Start)
+	if err != nil {
+		return err
+	}
+	end, err := ParseClock(w.End)
+	if err != nil {
+		return err
+	}
+	if start == end {
+		return fmt.Errorf("start and end must be different")
+	}
+	if w.Mode.rank() < 0 {
+		return fmt.Errorf("unknown quiet mode %q", w.Mode)
+	}
+	// Volume 0 berarti belum diatur (DefaultQuietVolume)
+	if w.Mode == QuietReducedVolume && (w.Volume < 0 || w.Volume > 100) {
+		return fmt.Errorf("volume must be between 1 and 100, or empty for %d%%", DefaultQuietVolume)
+	}
+	return nil
+}
+
+// Contains returns true if now (waktu lokal) falls inside the window
+func (w QuietWindow) Contains(now time.Time) bool {
+	start, err := ParseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(w.End)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(time.Local)
+	day := WeekdayOf(local)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return day == w.Day && minute >= start && minute < end
+	}
+	// Lewat tengah malam: bagian malam di Day, bagian pagi di hari berikutnya
+	return (day == w.Day && minute >= start) || (day == w.Day.Next() && minute < end)
+}
+
+// AudioVolume returns the ringtone volume in percent while the window is active
+func (w QuietWindow) AudioVolume() int {
+	switch w.Mode {
+	case QuietReducedVolume:
+		if w.Volume <= 0 {
+			return DefaultQuietVolume
+		}
+		return w.Volume
+	case QuietMuteSound, QuietSilent:
+		return 0
+	default:
+		return 100
+	}
+}
+
+// Summary menampilkan window, misalnya "Jumat 23:00-07:00 (Mute sound)"
+func (w QuietWindow) Summary() string {
+	text := fmt.Sprintf("%s %s-%s (%s", w.Day, w.Start, w.End, w.Mode.Label())
+	if w.Mode == QuietReducedVolume {
+		text += fmt.Sprintf(" %d%%", w.AudioVolume())
+	}
+	return text + ")"
+}
+
+// ActiveQuietWindow mencari window yang aktif pada now. Jika beberapa window
+// tumpang tindih, yang paling ketat (Silent > Mute > Reduced) dipakai.
+func ActiveQuietWindow(windows []QuietWindow, now time.Time) (QuietWindow, bool) {
+	var active QuietWindow
+	found := false
+	for _, w := range windows {
+		if !w.Contains(now) {
+			continue
+		}
+		if !found || w.Mode.rank() > active.Mode.rank() {
+			active = w
+			found = true
+		}
+	}
+	return active, found
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// ReminderEvent adalah satu reminder yang sudah di-fire, disimpan sebagai history
+type ReminderEvent struct {
+	Id         uint   `gorm:"primary_key;auto_increment"`
+	AnimeId    uint   `gorm:"index"`
+	AnimeTitle string `gorm:"size:255"`
+	SlotId     uint
+	// AirDate adalah tanggal tayang (zona siaran) yang di-reminder, lihat AiringState
+	AirDate string `gorm:"size:10"`
+	// OffsetMinutes > 0 untuk advance reminder (N menit sebelum tayang)
+	OffsetMinutes int
+	EpisodeNumber int
+	Title         string `gorm:"size:255"`
+	Message       string
+	FiredAt       time.Time `gorm:"index"`
+	AudioPlayed   bool
+	RingToneName  string `gorm:"size:255"`
+	// ResentFromId diisi jika event ini hasil "re-send" dari event lain
+	ResentFromId uint
+	Deliveries   []ReminderDelivery `gorm:"foreignKey:EventId"`
+	CreatedAt    time.Time
+}
+
+// ReminderDelivery adalah hasil pengiriman reminder ke satu channel (desktop, webhook, ...)
+type ReminderDelivery struct {
+	Id      uint   `gorm:"primary_key;auto_increment"`
+	EventId uint   `gorm:"index"`
+	Channel string `gorm:"size:50"`
+	Success bool
+	Error   string
+}
+
+// Delivered returns true if at least one channel delivered the reminder
+func (e ReminderEvent) Delivered() bool {
+	for _, d := range e.Deliveries {
+		if d.Success {
+			return true
+		}
+	}
+	return false
+}
+
+// ReminderEventFilter membatasi hasil query history. Zero value berarti tanpa filter.
+type ReminderEventFilter struct {
+	AnimeId uint
+	From    time.Time
+	To      time.Time
+	Limit   int
+}
+
+// Match returns true if event passes the filter
+func (f ReminderEventFilter) Match(event ReminderEvent) bool {
+	if f.AnimeId != 0 && event.AnimeId != f.AnimeId {
+		return false
+	}
+	if !f.From.IsZero() && event.FiredAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !event.FiredAt.Before(f.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+)
+
+// MaxOffsetMinutes adalah jarak terjauh advance reminder sebelum tayang (1 hari)
+const MaxOffsetMinutes = 24 * 60
+
+// DefaultOffsetTemplate dipakai jika offset tidak punya template sendiri
+const DefaultOffsetTemplate = "{{.Title}} starts in {{.Minutes}} minutes"
+
+// ReminderOffset adalah advance reminder beberapa menit sebelum tayang.
+// Disimpan sebagai JSON di kolom anime, atau sebagai default global di settings.
+type ReminderOffset struct {
+	Minutes int `json:"minutes"`
+	// Template adalah text/template untuk isi notifikasi, lihat OffsetMessage
+	Template string `json:"template,omitempty"`
+	// RingToneId 0 berarti tanpa suara
+	RingToneId uint `json:"ring_tone_id,omitempty"`
+}
+
+// OffsetMessage adalah data yang tersedia di template advance reminder
+type OffsetMessage struct {
+	Title   string // judul anime
+	Episode string // misalnya "Episode 7/12", kosong jika belum ada jadwal episode
+	Slot    string // label slot, misalnya "TV"
+	Minutes int    // sisa menit sampai tayang
+	Time    string // jam tayang lokal, misalnya "23:30"
+}
+
+// Render menghasilkan isi notifikasi dari template offset
+func (o ReminderOffset) Render(data OffsetMessage) (string, error) {
+	text := o.Template
+	if text == "" {
+		text = DefaultOffsetTemplate
+	}
+
+	tmpl, err := template.New("offset").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Validate memastikan menit masuk akal dan template bisa di-parse
+func (o ReminderOffset) Validate() error {
+	if o.Minutes < 1 || o.Minutes > MaxOffsetMinutes {
+		return fmt.Errorf("offset must be between 1 and %d minutes", MaxOffsetMinutes)
+	}
+	if o.Template != "" {
+		if _, err := template.New("offset").Parse(o.Template); err != nil {
+			return fmt.Errorf("invalid template for %d minutes: %v", o.Minutes, err)
+		}
+	}
+	return nil
+}
+
+// ValidateOffsets memvalidasi setiap offset dan menolak menit yang sama dua kali
+func ValidateOffsets(offsets []ReminderOffset) error {
+	seen := make(map[int]bool, len(offsets))
+	for _, offset := range offsets {
+		if err := offset.Validate(); err != nil {
+			return err
+		}
+		if seen[offset.Minutes] {
+			return fmt.Errorf("duplicate offset: %d minutes", offset.Minutes)
+		}
+		seen[offset.Minutes] = true
+	}
+	return nil
+}
+
+// SortOffsets mengurutkan offset dari yang paling jauh sebelum tayang
+func SortOffsets(offsets []ReminderOffset) {
+	sort.SliceStable(offsets, func(i, j int) bool { return offsets[i].Minutes > offsets[j].Minutes })
+}
+
+// LeadOffsets returns the anime's own offsets, or defaults if it has none
+func (a Anime) LeadOffsets(defaults []ReminderOffset) []ReminderOffset {
+	if len(a.ReminderOffsets) > 0 {
+		return a.ReminderOffsets
+	}
+	return defaults
+}
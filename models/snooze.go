@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Snooze adalah reminder yang ditunda dan akan dikirim ulang pada Until.
+// Disimpan di store supaya tetap ada setelah aplikasi di-restart.
+type Snooze struct {
+	Id uint `gorm:"primary_key;auto_increment"`
+	// EventId menunjuk ReminderEvent yang di-snooze
+	EventId   uint `gorm:"index"`
+	AnimeId   uint
+	SlotId    uint
+	Until     time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// SnoozeDurations adalah pilihan snooze cepat di notifikasi, tray dan popup
+var SnoozeDurations = []time.Duration{5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
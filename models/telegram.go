@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTelegramAPI adalah alamat Bot API resmi
+const DefaultTelegramAPI = "https://api.telegram.org"
+
+// Telegram adalah pengaturan bot yang mengirim reminder ke satu chat
+type Telegram struct {
+	Enabled  bool   `json:"enabled"`
+	BotToken string `json:"bot_token"`
+	// ChatId adalah id numerik chat/grup, atau @username channel
+	ChatId string `json:"chat_id"`
+	// BaseURL kosong = DefaultTelegramAPI, bisa diarahkan ke Bot API server sendiri
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// APIURL returns BaseURL without trailing slash, default DefaultTelegramAPI
+func (t Telegram) APIURL() string {
+	if t.BaseURL == "" {
+		return DefaultTelegramAPI
+	}
+	return strings.TrimRight(t.BaseURL, "/")
+}
+
+// Configured returns true if the bot is enabled and has a token and chat
+func (t Telegram) Configured() bool {
+	return t.Enabled && t.BotToken != "" && t.ChatId != ""
+}
+
+// Validate memastikan token dan chat diisi jika bot aktif
+func (t Telegram) Validate() error {
+	if t.BaseURL != "" && !strings.HasPrefix(t.BaseURL, "http://") && !strings.HasPrefix(t.BaseURL, "https://") {
+		return fmt.Errorf("Telegram API URL must start with http:// or https://")
+	}
+	if !t.Enabled {
+		return nil
+	}
+	if t.BotToken == "" {
+		return fmt.Errorf("please enter the Telegram bot token")
+	}
+	if t.ChatId == "" {
+		return fmt.Errorf("please enter the Telegram chat ID")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// WebhookPreset adalah bentuk payload bawaan untuk layanan chat populer
+type WebhookPreset string
+
+const (
+	WebhookDiscord WebhookPreset = "discord"
+	WebhookSlack   WebhookPreset = "slack"
+	// WebhookMatrix memakai endpoint send m.room.message (PUT dengan transaction id)
+	WebhookMatrix WebhookPreset = "matrix"
+	// WebhookCustom memakai Template milik webhook sendiri
+	WebhookCustom WebhookPreset = "custom"
+)
+
+// WebhookPresets adalah urutan preset di UI
+var WebhookPresets = []WebhookPreset{WebhookDiscord, WebhookSlack, WebhookMatrix, WebhookCustom}
+
+// Batas webhook, supaya satu server yang lambat tidak menahan pengiriman
+// (dan goroutine-nya) terlalu lama
+const (
+	DefaultWebhookTimeout = 10 * time.Second
+	MaxWebhookTimeout     = 60 * time.Second
+	MaxWebhookRetries     = 5
+)
+
+var webhookPresetTemplates = map[WebhookPreset]string{
+	WebhookDiscord: `{"username": "Anime Reminder", "embeds": [{"title": {{json .Title}}, "description": {{json .Body}}}]}`,
+	WebhookSlack:   `{"text": {{json (printf "*%s*\n%s" .Title .Body)}}}`,
+	WebhookMatrix:  `{"msgtype": "m.text", "body": {{json (printf "%s\n%s" .Title .Body)}}}`,
+	WebhookCustom:  `{"title": {{json .Title}}, "body": {{json .Body}}, "anime": {{json .AnimeTitle}}, "episode": {{json .Episode}}, "air_time": {{json .AirTime}}}`,
+}
+
+// Label returns the preset name for the UI
+func (p WebhookPreset) Label() string {
+	switch p {
+	case WebhookDiscord:
+		return "Discord"
+	case WebhookSlack:
+		return "Slack"
+	case WebhookMatrix:
+		return "Matrix"
+	case WebhookCustom:
+		return "Custom"
+	default:
+		return string(p)
+	}
+}
+
+// Template returns the default JSON body template of the preset
+func (p WebhookPreset) Template() string {
+	return webhookPresetTemplates[p]
+}
+
+// Method returns the HTTP method of the preset
+func (p WebhookPreset) Method() string {
+	if p == WebhookMatrix {
+		return "PUT"
+	}
+	return "POST"
+}
+
+// URLHint adalah contoh URL untuk placeholder di UI
+func (p WebhookPreset) URLHint() string {
+	switch p {
+	case WebhookDiscord:
+		return "https://discord.com/api/webhooks/<id>/<token>"
+	case WebhookSlack:
+		return "https://hooks.slack.com/services/..."
+	case WebhookMatrix:
+		return "https://<server>/_matrix/client/v3/rooms/<room id>/send/m.room.message/{{.TxnId}}"
+	default:
+		return "https://example.com/hook"
+	}
+}
+
+// Webhook adalah satu tujuan HTTP untuk reminder, misalnya channel Discord tim
+type Webhook struct {
+	Name    string        `json:"name"`
+	Enabled bool          `json:"enabled"`
+	Preset  WebhookPreset `json:"preset"`
+	// URL boleh berisi field template, misalnya {{.TxnId}} untuk Matrix
+	URL string `json:"url"`
+	// Method kosong = method preset
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Template adalah body JSON (text/template), kosong = template preset
+	Template       string `json:"template,omitempty"`
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	// Retries adalah jumlah percobaan ulang setelah gagal karena jaringan atau error 5xx/429
+	Retries int `json:"retries,omitempty"`
+}
+
+// WebhookMessage adalah data yang tersedia di template webhook
+type WebhookMessage struct {
+	Title      string // judul notifikasi, misalnya "🎬 Anime Time!"
+	Body       string // isi notifikasi lengkap
+	AnimeTitle string // kosong untuk notifikasi yang bukan milik satu anime
+	Episode    string // misalnya "Episode 7/12"
+	AirTime    string // jam tayang lokal "2006-01-02 15:04", kosong jika tidak diketahui
+	Urgency    string // "low", "normal" atau "critical"
+	// TxnId unik per request, dipakai Matrix supaya retry tidak mengirim pesan dua kali
+	TxnId string
+}
+
+// Timeout returns the request timeout, default DefaultWebhookTimeout
+func (w Webhook) Timeout() time.Duration {
+	if w.TimeoutSeconds <= 0 {
+		return DefaultWebhookTimeout
+	}
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// HTTPMethod returns Method or the preset's method
+func (w Webhook) HTTPMethod() string {
+	if w.Method != "" {
+		return strings.ToUpper(w.Method)
+	}
+	return w.Preset.Method()
+}
+
+// BodyTemplate returns Template or the preset's template
+func (w Webhook) BodyTemplate() string {
+	if w.Template != "" {
+		return w.Template
+	}
+	if text := w.Preset.Template(); text != "" {
+		return text
+	}
+	return WebhookCustom.Template()
+}
+
+// webhookFuncs tersedia di template; json meng-escape string menjadi literal JSON
+var webhookFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// Render menghasilkan URL dan body JSON untuk msg. Body yang bukan JSON valid ditolak.
+func (w Webhook) Render(msg WebhookMessage) (url string, body string, err error) {
+	url, err = executeWebhookTemplate("url", w.URL, msg)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid URL template: %v", err)
+	}
+	body, err = executeWebhookTemplate("body", w.BodyTemplate(), msg)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid body template: %v", err)
+	}
+	if !json.Valid([]byte(body)) {
+		return "", "", fmt.Errorf("webhook template did not produce valid JSON")
+	}
+	return strings.TrimSpace(url), body, nil
+}
+
+func executeWebhookTemplate(name, text string, msg WebhookMessage) (string, error) {
+	tmpl, err := template.New(name).Funcs(webhookFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Validate memastikan nama, URL, template dan batas waktu webhook masuk akal
+func (w Webhook) Validate() error {
+	if strings.TrimSpace(w.Name) == "" {
+		return fmt.Errorf("webhook name is required")
+	}
+	if w.Preset.Template() == "" {
+		return fmt.Errorf("unknown webhook preset %q", w.Preset)
+	}
+	if !strings.HasPrefix(w.URL, "http://") && !strings.HasPrefix(w.URL, "https://") {
+		return fmt.Errorf("webhook %s: URL must start with http:// or https://", w.Name)
+	}
+	if w.TimeoutSeconds < 0 || w.Timeout() > MaxWebhookTimeout {
+		return fmt.Errorf("webhook %s: timeout must be between 1 and %d seconds", w.Name, int(MaxWebhookTimeout/time.Second))
+	}
+	if w.Retries < 0 || w.Retries > MaxWebhookRetries {
+		return fmt.Errorf("webhook %s: retries must be between 0 and %d", w.Name, MaxWebhookRetries)
+	}
+
+	// Render dengan data contoh supaya template yang salah ketahuan saat disimpan
+	sample := WebhookMessage{
+		Title:      "🎬 Anime Time!",
+		Body:       `Sample "anime" is airing now!`,
+		AnimeTitle: `Sample "anime"`,
+		Episode:    "Episode 1/12",
+		AirTime:    "2006-01-02 15:04",
+		Urgency:    "normal",
+		TxnId:      "sample",
+	}
+	if _, _, err := w.Render(sample); err != nil {
+		return fmt.Errorf("webhook %s: %v", w.Name, err)
+	}
+	return nil
+}
+
+// ValidateWebhooks memvalidasi semua webhook dan memastikan namanya unik
+func ValidateWebhooks(webhooks []Webhook) error {
+	seen := make(map[string]bool)
+	for _, w := range webhooks {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+		if seen[w.Name] {
+			return fmt.Errorf("webhook name %q is used twice", w.Name)
+		}
+		seen[w.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Weekday adalah hari tayang, disimpan sebagai integer dengan nilai yang sama
+// seperti time.Weekday (Minggu = 0 ... Sabtu = 6). Nama hari hanya urusan tampilan.
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// WeekdayOrder adalah urutan hari di form dan daftar, dimulai dari Senin
+var WeekdayOrder = []Weekday{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
+
+// DefaultDayLocale dipakai jika locale sistem tidak dikenali (nama hari lama aplikasi)
+const DefaultDayLocale = "id"
+
+// dayNames berisi nama hari per locale, diindeks dengan Weekday
+var dayNames = map[string][7]string{
+	"id": {"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
+	"en": {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	"ja": {"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+}
+
+// DayLocales returns the supported locales for day names
+func DayLocales() []string {
+	return []string{"id", "en", "ja"}
+}
+
+// dayLocale adalah locale yang dipakai String(), diatur lewat SetDayLocale
+var dayLocale = DefaultDayLocale
+
+// SetDayLocale mengganti locale nama hari, locale yang tidak dikenal diabaikan
+func SetDayLocale(locale string) bool {
+	if _, ok := dayNames[locale]; !ok {
+		return false
+	}
+	dayLocale = locale
+	return true
+}
+
+// DayLocale returns the locale currently used for day names
+func DayLocale() string {
+	return dayLocale
+}
+
+// DetectDayLocale membaca locale sistem (LC_ALL, LC_TIME, LANG),
+// misalnya "en_US.UTF-8" menjadi "en"
+func DetectDayLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		value := os.Getenv(env)
+		if len(value) < 2 {
+			continue
+		}
+		locale := strings.ToLower(value[:2])
+		if _, ok := dayNames[locale]; ok {
+			return locale
+		}
+	}
+	return DefaultDayLocale
+}
+
+// WeekdayOf returns the weekday of t in its own location
+func WeekdayOf(t time.Time) Weekday {
+	return Weekday(t.Weekday())
+}
+
+// Valid returns true for Sunday..Saturday
+func (d Weekday) Valid() bool {
+	return d >= Sunday && d <= Saturday
+}
+
+// Time converts d to time.Weekday
+func (d Weekday) Time() time.Weekday {
+	return time.Weekday(d)
+}
+
+// Next returns the following day (Saturday → Sunday)
+func (d Weekday) Next() Weekday {
+	return (d + 1) % 7
+}
+
+// Prev returns the previous day (Sunday → Saturday)
+func (d Weekday) Prev() Weekday {
+	return (d + 6) % 7
+}
+
+// Name returns the day name in locale, falling back to DefaultDayLocale
+func (d Weekday) Name(locale string) string {
+	if !d.Valid() {
+		return fmt.Sprintf("Weekday(%d)", int(d))
+	}
+	names, ok := dayNames[locale]
+	if !ok {
+		names = dayNames[DefaultDayLocale]
+	}
+	return names[d]
+}
+
+// String returns the day name in the current display locale
+func (d Weekday) String() string {
+	return d.Name(dayLocale)
+}
+
+// ParseWeekday menerima nama hari dari locale mana pun tanpa peduli huruf besar/kecil
+// ("senin", "Monday", "月曜日"), juga singkatan tiga huruf ("mon") dan angka 0-6
+func ParseWeekday(text string) (Weekday, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return Sunday, fmt.Errorf("empty day")
+	}
+
+	var number int
+	if _, err := fmt.Sscanf(text, "%d", &number); err == nil && fmt.Sprint(number) == text {
+		if d := Weekday(number); d.Valid() {
+			return d, nil
+		}
+		return Sunday, fmt.Errorf("invalid day %q", text)
+	}
+
+	for _, names := range dayNames {
+		for i, name := range names {
+			name = strings.ToLower(name)
+			if text == name || (len([]rune(text)) >= 3 && strings.HasPrefix(name, text)) {
+				return Weekday(i), nil
+			}
+		}
+	}
+	return Sunday, fmt.Errorf("invalid day %q", text)
+}
+
+// UnmarshalJSON menerima angka, atau nama hari dari file JSON lama ("Senin")
+func (d *Weekday) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		if !Weekday(number).Valid() {
+			return fmt.Errorf("invalid day %d", number)
+		}
+		*d = Weekday(number)
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParseWeekday(name)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
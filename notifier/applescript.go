@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// AppleScript mengirim notifikasi macOS lewat osascript
+type AppleScript struct{}
+
+func (AppleScript) Name() string { return "osascript" }
+
+func (AppleScript) Available() bool { return runtime.GOOS == "darwin" }
+
+func (AppleScript) Send(n Notification) error {
+	script := fmt.Sprintf(`display notification "%s" with title "%s"`, appleScriptEscape(n.Body), appleScriptEscape(n.Title))
+	return exec.Command("osascript", "-e", script).Run()
+}
+
+// appleScriptEscape meng-escape tanda kutip dan backslash di string AppleScript
+func appleScriptEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)
+}
@@ -0,0 +1,220 @@
+//go:build linux
+
+package notifier
+
+import (
+	"errors"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusDest      = "org.freedesktop.Notifications"
+	dbusPath      = dbus.ObjectPath("/org/freedesktop/Notifications")
+	dbusInterface = "org.freedesktop.Notifications"
+
+	// dbusDefaultAction adalah aksi yang dikirim server saat badan notifikasi diklik
+	dbusDefaultAction = "default"
+)
+
+// DBus adalah client org.freedesktop.Notifications langsung di session bus,
+// tanpa perlu binary notify-send. Mendukung tombol aksi, replace-id (lewat
+// Notification.Tag), hint urgency dan gambar.
+type DBus struct {
+	connect func() (*dbus.Conn, error)
+
+	mu   sync.Mutex
+	conn *dbus.Conn
+	caps map[string]bool
+	// callbacks menyimpan OnAction per id notifikasi sampai notifikasi ditutup
+	callbacks map[uint32]func(key string)
+	// tags menyimpan id notifikasi terakhir per Tag untuk replace-id
+	tags map[string]uint32
+}
+
+// NewDBus membuat client dengan fungsi koneksi sendiri, misalnya ke bus privat
+// (dbus.Connect ke alamat lain) untuk pengujian
+func NewDBus(connect func() (*dbus.Conn, error)) *DBus {
+	return &DBus{
+		connect:   connect,
+		callbacks: make(map[uint32]func(key string)),
+		tags:      make(map[string]uint32),
+	}
+}
+
+var dbusNotifier Notifier = NewDBus(func() (*dbus.Conn, error) { return dbus.ConnectSessionBus() })
+
+func (d *DBus) Name() string { return "dbus" }
+
+func (d *DBus) Available() bool {
+	_, err := d.connection()
+	return err == nil
+}
+
+// connection membuka koneksi (sekali) dan mulai mendengarkan sinyal aksi
+func (d *DBus) connection() (*dbus.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.conn != nil && d.conn.Connected() {
+		return d.conn, nil
+	}
+
+	conn, err := d.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	var caps []string
+	if err := conn.Object(dbusDest, dbusPath).Call(dbusInterface+".GetCapabilities", 0).Store(&caps); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	d.caps = make(map[string]bool, len(caps))
+	for _, c := range caps {
+		d.caps[c] = true
+	}
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(dbusPath),
+		dbus.WithMatchInterface(dbusInterface),
+	); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	signals := make(chan *dbus.Signal, 16)
+	conn.Signal(signals)
+	go d.listen(signals)
+
+	d.conn = conn
+	return conn, nil
+}
+
+// listen meneruskan ActionInvoked ke callback notifikasi, sampai koneksi ditutup
+func (d *DBus) listen(signals <-chan *dbus.Signal) {
+	for signal := range signals {
+		switch signal.Name {
+		case dbusInterface + ".ActionInvoked":
+			var id uint32
+			var key string
+			if err := dbus.Store(signal.Body, &id, &key); err != nil {
+				continue
+			}
+			d.mu.Lock()
+			callback := d.callbacks[id]
+			d.mu.Unlock()
+			if callback != nil {
+				go callback(key)
+			}
+		case dbusInterface + ".NotificationClosed":
+			var id, reason uint32
+			if err := dbus.Store(signal.Body, &id, &reason); err != nil {
+				continue
+			}
+			d.forget(id)
+		}
+	}
+}
+
+// forget menghapus callback dan tag milik notifikasi yang sudah ditutup
+func (d *DBus) forget(id uint32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.callbacks, id)
+	for tag, tagID := range d.tags {
+		if tagID == id {
+			delete(d.tags, tag)
+		}
+	}
+}
+
+func (d *DBus) Send(n Notification) error {
+	conn, err := d.connection()
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	replaces := d.tags[n.Tag]
+	markup := d.caps["body-markup"]
+	withActions := d.caps["actions"]
+	d.mu.Unlock()
+
+	body := n.Body
+	if markup {
+		body = markupEscape(body)
+	}
+
+	var actions []string
+	if withActions {
+		for _, action := range n.Actions {
+			actions = append(actions, action.Key, action.Label)
+			// Klik pada badan notifikasi sama dengan tombol Open
+			if action.Key == ActionOpen {
+				actions = append(actions, dbusDefaultAction, action.Label)
+			}
+		}
+	}
+
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(byte(n.Urgency)),
+	}
+	if n.Icon != "" {
+		if path, err := filepath.Abs(n.Icon); err == nil {
+			hints["image-path"] = dbus.MakeVariant("file://" + path)
+		}
+	}
+
+	var id uint32
+	err = conn.Object(dbusDest, dbusPath).Call(dbusInterface+".Notify", 0,
+		"Anime Reminder", replaces, "", n.Title, body, actions, hints, int32(-1),
+	).Store(&id)
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		return errors.New("notification server returned id 0")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if replaces != 0 && replaces != id {
+		delete(d.callbacks, replaces)
+	}
+	if n.Tag != "" {
+		d.tags[n.Tag] = id
+	}
+	if n.OnAction != nil && len(actions) > 0 {
+		onAction := n.OnAction
+		d.callbacks[id] = func(key string) {
+			if key == dbusDefaultAction {
+				key = ActionOpen
+			}
+			onAction(key)
+		}
+	} else {
+		delete(d.callbacks, id)
+	}
+	return nil
+}
+
+// Close menutup koneksi D-Bus
+func (d *DBus) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conn != nil {
+		if err := d.conn.Close(); err != nil {
+			log.Printf("⚠️ Failed to close D-Bus connection: %v", err)
+		}
+		d.conn = nil
+	}
+}
+
+// markupEscape meng-escape teks untuk server yang mendukung body-markup
+func markupEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
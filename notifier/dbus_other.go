@@ -0,0 +1,18 @@
+//go:build !linux
+
+package notifier
+
+import "errors"
+
+// DBus hanya tersedia di Linux
+type DBus struct{}
+
+var dbusNotifier Notifier = &DBus{}
+
+func (d *DBus) Name() string { return "dbus" }
+
+func (d *DBus) Available() bool { return false }
+
+func (d *DBus) Send(n Notification) error {
+	return errors.New("D-Bus notifications are only supported on Linux")
+}
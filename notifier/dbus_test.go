@@ -0,0 +1,252 @@
+//go:build linux
+
+package notifier
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// notifyCall adalah argumen satu panggilan Notify yang diterima server palsu
+type notifyCall struct {
+	// ID adalah id yang dikembalikan server untuk panggilan ini
+	ID       uint32
+	AppName  string
+	Replaces uint32
+	Summary  string
+	Body     string
+	Actions  []string
+	Hints    map[string]dbus.Variant
+}
+
+// fakeNotificationServer adalah org.freedesktop.Notifications palsu di bus privat
+type fakeNotificationServer struct {
+	caps []string
+
+	mu     sync.Mutex
+	nextID uint32
+	calls  chan notifyCall
+	// emit mengirim sinyal ActionInvoked dari koneksi server
+	emit func(id uint32, key string) error
+}
+
+func (f *fakeNotificationServer) GetCapabilities() ([]string, *dbus.Error) {
+	return f.caps, nil
+}
+
+func (f *fakeNotificationServer) Notify(appName string, replaces uint32, icon, summary, body string,
+	actions []string, hints map[string]dbus.Variant, timeout int32) (uint32, *dbus.Error) {
+	// Seperti server sungguhan: replace-id memakai id yang sama
+	id := replaces
+	if id == 0 {
+		f.mu.Lock()
+		f.nextID++
+		id = f.nextID
+		f.mu.Unlock()
+	}
+
+	f.calls <- notifyCall{ID: id, AppName: appName, Replaces: replaces, Summary: summary, Body: body, Actions: actions, Hints: hints}
+	return id, nil
+}
+
+// startPrivateBus menjalankan dbus-daemon sendiri dan mengembalikan alamatnya
+func startPrivateBus(t *testing.T) string {
+	t.Helper()
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not installed")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--nopidfile", "--print-address=1",
+		"--address=unix:path="+filepath.Join(t.TempDir(), "bus"))
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("stdout pipe: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("failed to start dbus-daemon: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read bus address: %v", err)
+	}
+	return strings.TrimSpace(address)
+}
+
+// startFakeServer mendaftarkan server palsu di bus dan membuat client DBus ke bus yang sama
+func startFakeServer(t *testing.T, caps []string) (*fakeNotificationServer, *DBus) {
+	t.Helper()
+	address := startPrivateBus(t)
+
+	serverConn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	t.Cleanup(func() { serverConn.Close() })
+
+	server := &fakeNotificationServer{caps: caps, calls: make(chan notifyCall, 8)}
+	if err := serverConn.Export(server, dbusPath, dbusInterface); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	reply, err := serverConn.RequestName(dbusDest, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("request name: %v (reply %d)", err, reply)
+	}
+
+	client := NewDBus(func() (*dbus.Conn, error) { return dbus.Connect(address) })
+	t.Cleanup(client.Close)
+
+	// Sinyal dikirim dari koneksi server, sama seperti notification daemon
+	server.emit = func(id uint32, key string) error {
+		return serverConn.Emit(dbusPath, dbusInterface+".ActionInvoked", id, key)
+	}
+	return server, client
+}
+
+func (f *fakeNotificationServer) nextCall(t *testing.T) notifyCall {
+	t.Helper()
+	select {
+	case call := <-f.calls:
+		return call
+	case <-time.After(5 * time.Second):
+		t.Fatal("Notify was not called")
+		return notifyCall{}
+	}
+}
+
+func TestDBusSendArguments(t *testing.T) {
+	server, client := startFakeServer(t, []string{"actions", "body", "body-markup"})
+	if !client.Available() {
+		t.Fatal("client not available on private bus")
+	}
+
+	dir := t.TempDir()
+	icon := filepath.Join(dir, "cover.png")
+	if err := os.WriteFile(icon, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	n := Notification{
+		Title:   "🎬 Anime Reminder",
+		Body:    "Frieren <Episode 7>",
+		Icon:    icon,
+		Urgency: UrgencyCritical,
+		Actions: []Action{{Key: "snooze-10", Label: "Snooze 10 min"}, {Key: ActionOpen, Label: "Open"}, {Key: "dismiss", Label: "Dismiss"}},
+		Tag:     "airing-1-1-2026-10-17",
+	}
+	if err := client.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	call := server.nextCall(t)
+	if call.AppName != "Anime Reminder" || call.Summary != n.Title {
+		t.Errorf("app/summary = %q/%q", call.AppName, call.Summary)
+	}
+	if call.Body != "Frieren &lt;Episode 7&gt;" {
+		t.Errorf("body = %q, want markup-escaped text", call.Body)
+	}
+	if call.Replaces != 0 {
+		t.Errorf("first notification replaces %d, want 0", call.Replaces)
+	}
+	wantActions := []string{"snooze-10", "Snooze 10 min", ActionOpen, "Open", dbusDefaultAction, "Open", "dismiss", "Dismiss"}
+	if !reflect.DeepEqual(call.Actions, wantActions) {
+		t.Errorf("actions = %q, want %q", call.Actions, wantActions)
+	}
+	if urgency, ok := call.Hints["urgency"].Value().(byte); !ok || urgency != byte(UrgencyCritical) {
+		t.Errorf("urgency hint = %v, want byte %d", call.Hints["urgency"], UrgencyCritical)
+	}
+	if image, _ := call.Hints["image-path"].Value().(string); image != "file://"+icon {
+		t.Errorf("image-path hint = %q, want %q", image, "file://"+icon)
+	}
+}
+
+func TestDBusReplacesByTag(t *testing.T) {
+	server, client := startFakeServer(t, []string{"actions", "body"})
+
+	countdown := Notification{Title: "⏰ Starting Soon", Body: "Frieren starts in 15 minutes", Tag: "airing-1-1-2026-10-17"}
+	if err := client.Send(countdown); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	first := server.nextCall(t)
+
+	countdown.Body = "Frieren starts in 5 minutes"
+	if err := client.Send(countdown); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	second := server.nextCall(t)
+	if second.Replaces != first.ID {
+		t.Errorf("second notification replaces %d, want %d", second.Replaces, first.ID)
+	}
+
+	if err := client.Send(Notification{Title: "Other", Tag: "airing-2-1-2026-10-17"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if other := server.nextCall(t); other.Replaces != 0 {
+		t.Errorf("notification with another tag replaces %d, want 0", other.Replaces)
+	}
+
+	if err := client.Send(Notification{Title: "Untagged"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if untagged := server.nextCall(t); untagged.Replaces != 0 {
+		t.Errorf("untagged notification replaces %d, want 0", untagged.Replaces)
+	}
+}
+
+func TestDBusActionInvoked(t *testing.T) {
+	server, client := startFakeServer(t, []string{"actions", "body"})
+
+	keys := make(chan string, 4)
+	n := Notification{
+		Title:    "🎬 Anime Reminder",
+		Actions:  []Action{{Key: "snooze-10", Label: "Snooze 10 min"}, {Key: ActionOpen, Label: "Open"}},
+		OnAction: func(key string) { keys <- key },
+	}
+	if err := client.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	call := server.nextCall(t)
+
+	for _, tc := range []struct{ sent, want string }{
+		{dbusDefaultAction, ActionOpen},
+		{"snooze-10", "snooze-10"},
+	} {
+		if err := server.emit(call.ID, tc.sent); err != nil {
+			t.Fatalf("emit: %v", err)
+		}
+		select {
+		case key := <-keys:
+			if key != tc.want {
+				t.Errorf("OnAction(%q) for %q, want %q", key, tc.sent, tc.want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("OnAction not called for %q", tc.sent)
+		}
+	}
+}
+
+func TestDBusWithoutActionCapability(t *testing.T) {
+	server, client := startFakeServer(t, []string{"body"})
+
+	n := Notification{Title: "🎬 Anime Reminder", Actions: []Action{{Key: ActionOpen, Label: "Open"}}}
+	if err := client.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if call := server.nextCall(t); len(call.Actions) != 0 {
+		t.Errorf("actions = %q, want none when the server lacks the actions capability", call.Actions)
+	}
+}
@@ -0,0 +1,208 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// emailTimeout adalah batas waktu satu sesi SMTP, dari connect sampai QUIT
+const emailTimeout = 30 * time.Second
+
+// Email mengirim notifikasi lewat SMTP sebagai email HTML + plain-text,
+// dengan cover anime sebagai gambar inline
+type Email struct {
+	Config models.Email
+	// TLSConfig nil berarti sertifikat diverifikasi untuk Config.Host
+	TLSConfig *tls.Config
+}
+
+// NewEmail membuat backend email dari settings
+func NewEmail(cfg models.Email) *Email {
+	return &Email{Config: cfg}
+}
+
+func (e *Email) Name() string { return "email" }
+
+func (e *Email) Available() bool { return e.Config.Configured() }
+
+// ReceivesDigest: email juga dipakai untuk digest jika diaktifkan user
+func (e *Email) ReceivesDigest() bool { return e.Config.Digest }
+
+func (e *Email) Send(n Notification) error {
+	from, err := mail.ParseAddress(e.Config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %v", err)
+	}
+	recipients, err := e.Config.Recipients()
+	if err != nil {
+		return err
+	}
+
+	// Cover sudah berupa PNG kecil dari cache (utils.CoverIcon)
+	var cover []byte
+	if n.Icon != "" {
+		cover, _ = os.ReadFile(n.Icon)
+	}
+
+	msg := models.EmailMessage{
+		Title:      n.Title,
+		Body:       n.Body,
+		AnimeTitle: n.AnimeTitle,
+		Episode:    n.Episode,
+		Cover:      len(cover) > 0,
+	}
+	if !n.AirAt.IsZero() {
+		msg.AirTime = n.AirAt.In(time.Local).Format("Mon 2006-01-02 15:04")
+	}
+	text, htmlBody, err := e.Config.Render(msg)
+	if err != nil {
+		return err
+	}
+
+	data := buildEmail(from, recipients, n.Title, text, htmlBody, cover)
+	return e.deliver(from.Address, recipients, data)
+}
+
+// deliver menjalankan satu sesi SMTP: TLS/STARTTLS, AUTH, MAIL, RCPT, DATA
+func (e *Email) deliver(from string, recipients []string, data []byte) error {
+	cfg := e.Config
+	tlsConfig := e.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{ServerName: cfg.Host}
+	}
+
+	dialer := &net.Dialer{Timeout: emailTimeout}
+	var conn net.Conn
+	var err error
+	if cfg.SecurityMode() == models.EmailTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", cfg.Address(), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", cfg.Address())
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(emailTimeout))
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if cfg.SecurityMode() == models.EmailStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS", cfg.Host)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS failed: %v", err)
+		}
+	}
+
+	if cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("%s does not support authentication", cfg.Host)
+		}
+		// PlainAuth menolak mengirim password lewat koneksi tanpa TLS (kecuali localhost)
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("login failed: %v", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %s rejected: %v", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildEmail menyusun pesan MIME: multipart/alternative (plain + HTML), dibungkus
+// multipart/related jika ada cover inline
+func buildEmail(from *mail.Address, to []string, subject, text, htmlBody string, cover []byte) []byte {
+	var alt bytes.Buffer
+	altWriter := multipart.NewWriter(&alt)
+	writeQuotedPart(altWriter, "text/plain; charset=utf-8", text)
+	writeQuotedPart(altWriter, "text/html; charset=utf-8", htmlBody)
+	altWriter.Close()
+	altType := "multipart/alternative; boundary=" + altWriter.Boundary()
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", from.String())
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%d@anime-reminder>", time.Now().UnixNano()))
+	header("MIME-Version", "1.0")
+
+	if len(cover) == 0 {
+		header("Content-Type", altType)
+		buf.WriteString("\r\n")
+		buf.Write(alt.Bytes())
+		return buf.Bytes()
+	}
+
+	related := multipart.NewWriter(&buf)
+	header("Content-Type", fmt.Sprintf(`multipart/related; type="multipart/alternative"; boundary=%s`, related.Boundary()))
+	buf.WriteString("\r\n")
+
+	part, _ := related.CreatePart(textproto.MIMEHeader{"Content-Type": {altType}})
+	part.Write(alt.Bytes())
+
+	part, _ = related.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"image/png"},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-ID":                {"<" + models.EmailCoverCID + ">"},
+		"Content-Disposition":       {`inline; filename="cover.png"`},
+	})
+	encoded := base64.StdEncoding.EncodeToString(cover)
+	for len(encoded) > 76 {
+		part.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	part.Write([]byte(encoded + "\r\n"))
+	related.Close()
+	return buf.Bytes()
+}
+
+// writeQuotedPart menulis satu part teks dengan quoted-printable (aman untuk emoji dan baris panjang)
+func writeQuotedPart(w *multipart.Writer, contentType, content string) {
+	part, _ := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	qp := quotedprintable.NewWriter(part)
+	qp.Write([]byte(content))
+	qp.Close()
+}
@@ -0,0 +1,393 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testCertificates membuat CA sementara dan sertifikat server untuk 127.0.0.1
+func testCertificates(t *testing.T) (server tls.Certificate, roots *x509.CertPool) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Anime Reminder Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roots = x509.NewCertPool()
+	roots.AddCert(ca)
+	return tls.Certificate{Certificate: [][]byte{leafDER}, PrivateKey: key}, roots
+}
+
+// smtpSession adalah apa yang diterima server palsu dalam satu sesi
+type smtpSession struct {
+	StartTLS bool
+	// TLS true jika AUTH dikirim lewat koneksi terenkripsi
+	TLS  bool
+	Auth string
+	From string
+	To   []string
+	Data []byte
+}
+
+// fakeSMTPServer adalah server SMTP minimal di net.Listener
+type fakeSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	// startTLS false berarti server tidak menawarkan STARTTLS
+	startTLS bool
+
+	sessions chan smtpSession
+}
+
+// startFakeSMTP menjalankan server palsu. implicitTLS membungkus listener dengan TLS.
+func startFakeSMTP(t *testing.T, cert tls.Certificate, implicitTLS, startTLS bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if implicitTLS {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	f := &fakeSMTPServer{listener: listener, tlsConfig: tlsConfig, startTLS: startTLS, sessions: make(chan smtpSession, 4)}
+
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		listener.Close()
+		wg.Wait()
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				f.serve(conn, implicitTLS)
+			}()
+		}
+	}()
+	return f
+}
+
+// config returns the email settings pointing at the fake server
+func (f *fakeSMTPServer) config(security models.EmailSecurity) models.Email {
+	_, port, _ := net.SplitHostPort(f.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return models.Email{
+		Enabled:  true,
+		Host:     "127.0.0.1",
+		Port:     portNumber,
+		Security: security,
+		Username: "reminder",
+		Password: "s3cret",
+		From:     "Anime Reminder <bot@example.com>",
+		To:       "alice@example.com, Bob <bob@example.com>",
+	}
+}
+
+func (f *fakeSMTPServer) serve(conn net.Conn, encrypted bool) {
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	text := textproto.NewConn(conn)
+	reply := func(format string, args ...interface{}) {
+		text.PrintfLine(format, args...)
+	}
+
+	session := smtpSession{}
+	reply("220 fake ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-fake")
+			if f.startTLS && !encrypted {
+				reply("250-STARTTLS")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready")
+			tlsConn := tls.Server(conn, f.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			encrypted = true
+			session.StartTLS = true
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			decoded, err := base64.StdEncoding.DecodeString(initial)
+			if mechanism != "PLAIN" || err != nil {
+				reply("504 unsupported")
+				continue
+			}
+			session.Auth = string(decoded)
+			session.TLS = encrypted
+			reply("235 ok")
+		case "MAIL":
+			session.From = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			session.To = append(session.To, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			session.Data = data
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			f.sessions <- session
+			return
+		default:
+			reply("502 unknown command")
+		}
+	}
+}
+
+func (f *fakeSMTPServer) nextSession(t *testing.T) smtpSession {
+	t.Helper()
+	select {
+	case session := <-f.sessions:
+		return session
+	case <-time.After(5 * time.Second):
+		t.Fatal("SMTP session did not finish")
+		return smtpSession{}
+	}
+}
+
+// writeCover menulis "PNG" palsu; isinya hanya perlu sama setelah base64
+func writeCover(t *testing.T) (string, []byte) {
+	t.Helper()
+	cover := bytes.Repeat([]byte("\x89PNG\r\n\x1a\ncover"), 20)
+	path := filepath.Join(t.TempDir(), "cover.png")
+	if err := os.WriteFile(path, cover, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path, cover
+}
+
+var emailNotification = Notification{
+	Title:      "🎬 Anime Time!",
+	Body:       "Frieren is airing now!\nEpisode 7/12",
+	AnimeTitle: "Frieren",
+	Episode:    "Episode 7/12",
+	AirAt:      time.Date(2026, 10, 17, 23, 0, 0, 0, time.Local),
+}
+
+func TestEmailSecurityModes(t *testing.T) {
+	cert, roots := testCertificates(t)
+
+	tests := []struct {
+		name        string
+		security    models.EmailSecurity
+		implicitTLS bool
+		wantStart   bool
+	}{
+		{name: "STARTTLS", security: models.EmailStartTLS, wantStart: true},
+		{name: "implicit TLS", security: models.EmailTLS, implicitTLS: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startFakeSMTP(t, cert, tt.implicitTLS, true)
+			email := NewEmail(server.config(tt.security))
+			email.TLSConfig = &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+
+			if err := email.Send(emailNotification); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			session := server.nextSession(t)
+			if session.StartTLS != tt.wantStart {
+				t.Errorf("STARTTLS used = %v, want %v", session.StartTLS, tt.wantStart)
+			}
+			if !session.TLS {
+				t.Error("AUTH was sent without TLS")
+			}
+			if session.Auth != "\x00reminder\x00s3cret" {
+				t.Errorf("AUTH PLAIN = %q", session.Auth)
+			}
+			if session.From != "bot@example.com" {
+				t.Errorf("MAIL FROM = %q", session.From)
+			}
+			if strings.Join(session.To, ",") != "alice@example.com,bob@example.com" {
+				t.Errorf("RCPT TO = %v", session.To)
+			}
+		})
+	}
+}
+
+func TestEmailRequiresStartTLS(t *testing.T) {
+	cert, roots := testCertificates(t)
+	server := startFakeSMTP(t, cert, false, false)
+	email := NewEmail(server.config(models.EmailStartTLS))
+	email.TLSConfig = &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+
+	err := email.Send(emailNotification)
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("Send error = %v, want STARTTLS refusal", err)
+	}
+}
+
+func TestEmailUntrustedCertificate(t *testing.T) {
+	cert, _ := testCertificates(t)
+	server := startFakeSMTP(t, cert, true, false)
+	// TLSConfig nil: sertifikat diverifikasi dengan root sistem
+	email := NewEmail(server.config(models.EmailTLS))
+
+	if err := email.Send(emailNotification); err == nil {
+		t.Error("Send accepted a certificate from an unknown CA")
+	}
+}
+
+func TestEmailMIMEWithCover(t *testing.T) {
+	cert, roots := testCertificates(t)
+	server := startFakeSMTP(t, cert, false, true)
+	email := NewEmail(server.config(models.EmailStartTLS))
+	email.TLSConfig = &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+
+	n := emailNotification
+	iconPath, cover := writeCover(t)
+	n.Icon = iconPath
+	if err := email.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(server.nextSession(t).Data))
+	if err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != n.Title {
+		t.Errorf("Subject = %q, want %q", subject, n.Title)
+	}
+	if to := msg.Header.Get("To"); to != "alice@example.com, bob@example.com" {
+		t.Errorf("To = %q", to)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/related" || params["type"] != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q", msg.Header.Get("Content-Type"))
+	}
+	related := multipart.NewReader(msg.Body, params["boundary"])
+
+	// Part pertama: alternative dengan plain text lalu HTML
+	altPart, err := related.NextPart()
+	if err != nil {
+		t.Fatalf("alternative part: %v", err)
+	}
+	altType, altParams, _ := mime.ParseMediaType(altPart.Header.Get("Content-Type"))
+	if altType != "multipart/alternative" {
+		t.Fatalf("first part = %q, want multipart/alternative", altType)
+	}
+	alternative := multipart.NewReader(altPart, altParams["boundary"])
+	for _, want := range []string{"text/plain", "text/html"} {
+		part, err := alternative.NextPart()
+		if err != nil {
+			t.Fatalf("%s part: %v", want, err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		// multipart.Reader sudah men-decode quoted-printable
+		body, _ := io.ReadAll(part)
+		if partType != want || !strings.Contains(string(body), "Frieren is airing now!") {
+			t.Errorf("part %s = %s: %q", want, partType, body)
+		}
+		if want == "text/html" && !strings.Contains(string(body), `src="cid:`+models.EmailCoverCID+`"`) {
+			t.Errorf("HTML does not reference the inline cover: %q", body)
+		}
+	}
+	if _, err := alternative.NextPart(); err != io.EOF {
+		t.Errorf("alternative has extra parts (err %v)", err)
+	}
+
+	// Part kedua: cover PNG inline
+	imagePart, err := related.NextPart()
+	if err != nil {
+		t.Fatalf("image part: %v", err)
+	}
+	if got := imagePart.Header.Get("Content-Type"); got != "image/png" {
+		t.Errorf("image Content-Type = %q", got)
+	}
+	if got := imagePart.Header.Get("Content-Transfer-Encoding"); got != "base64" {
+		t.Errorf("image Content-Transfer-Encoding = %q", got)
+	}
+	if got := imagePart.Header.Get("Content-ID"); got != "<cover@anime-reminder>" {
+		t.Errorf("image Content-ID = %q", got)
+	}
+	encoded, _ := io.ReadAll(imagePart)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	if err != nil || !bytes.Equal(decoded, cover) {
+		t.Errorf("cover did not survive base64 (err %v)", err)
+	}
+	if _, err := related.NextPart(); err != io.EOF {
+		t.Errorf("related has extra parts (err %v)", err)
+	}
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"net/http"
+)
+
+// Gotify mengirim notifikasi ke server Gotify lewat endpoint /message
+type Gotify struct {
+	Config models.Gotify
+	// Client nil berarti http.Client dengan pushTimeout
+	Client *http.Client
+}
+
+// NewGotify membuat backend Gotify dari settings
+func NewGotify(cfg models.Gotify) *Gotify {
+	return &Gotify{Config: cfg}
+}
+
+func (g *Gotify) Name() string { return "gotify" }
+
+func (g *Gotify) Available() bool { return g.Config.Configured() }
+
+// gotifyMessage adalah body POST /message
+type gotifyMessage struct {
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+func (g *Gotify) Send(n Notification) error {
+	message := gotifyMessage{
+		Title:    emojiPrefix(pushTags(n, g.Config.Tags), n.Title),
+		Message:  n.Body,
+		Priority: pushPriority(g.Config.PriorityMap(), n.Urgency),
+	}
+	// Aplikasi Android Gotify membuka URL ini saat notifikasi diklik
+	if click := pushClickURL(n, g.Config.ClickURL); click != "" {
+		message.Extras = map[string]interface{}{
+			"client::notification": map[string]interface{}{
+				"click": map[string]string{"url": click},
+			},
+		}
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{"X-Gotify-Key": g.Config.AppToken}
+	return postJSON(pushClient(g.Client), http.MethodPost, g.Config.Server()+"/message", headers, body)
+}
@@ -0,0 +1,239 @@
+package notifier
+
+import (
+	"anime-reminder/settings"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Urgency adalah tingkat kepentingan notifikasi, dipetakan ke hint masing-masing backend
+type Urgency int
+
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical
+)
+
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "low"
+	case UrgencyCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// Action adalah tombol di notifikasi, Key dikirim ke OnAction saat diklik
+type Action struct {
+	Key   string
+	Label string
+}
+
+// ActionOpen adalah key aksi untuk membuka aplikasi. Backend yang mendukung klik
+// pada badan notifikasi (D-Bus "default") memetakannya ke key ini.
+const ActionOpen = "open"
+
+// Notification adalah isi notifikasi yang dikirim ke semua backend
+type Notification struct {
+	Title string
+	Body  string
+	// Icon adalah path file gambar (opsional)
+	Icon    string
+	Urgency Urgency
+	Actions []Action
+	// AnimeId 0 untuk notifikasi yang bukan milik satu anime (digest, ringkasan, test)
+	AnimeId uint
+	// AnimeTitle, Episode dan AirAt adalah data mentah reminder untuk backend
+	// yang menyusun pesannya sendiri (webhook, email, ...). Kosong untuk digest/test.
+	AnimeTitle string
+	Episode    string
+	AirAt      time.Time
+	// URL dibuka saat notifikasi di HP diklik, misalnya stream link anime
+	URL string
+	// Tags adalah emoji shortcode ("tv", "alarm_clock") untuk backend push
+	Tags []string
+	// Tag mengelompokkan notifikasi yang saling menggantikan, misalnya hitung mundur
+	// advance reminder untuk satu tayangan (replace-id di D-Bus)
+	Tag string
+	// OnAction dipanggil dari goroutine lain dengan Key tombol yang diklik
+	OnAction func(key string)
+}
+
+// Notifier adalah satu backend pengiriman notifikasi
+type Notifier interface {
+	// Name adalah id backend, dipakai di settings dan history (ReminderDelivery.Channel)
+	Name() string
+	// Available returns true if the backend can run on this system
+	Available() bool
+	Send(n Notification) error
+}
+
+// Delivery adalah hasil satu percobaan pengiriman
+type Delivery struct {
+	Backend string
+	Err     error
+}
+
+// ErrNoBackend dikembalikan jika tidak ada backend yang aktif dan tersedia
+var ErrNoBackend = errors.New("no notification backend available")
+
+var (
+	mu sync.RWMutex
+	// chain adalah urutan fallback: backend pertama yang berhasil menghentikan pengiriman
+	chain = []Notifier{
+		dbusNotifier,
+		NotifySend{},
+		WindowsToast{},
+		AppleScript{},
+		popup,
+	}
+)
+
+// Register menambahkan backend ke fallback chain sebelum backend before
+// (kosong = di akhir). Backend dengan nama sama diganti.
+func Register(n Notifier, before string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i, existing := range chain {
+		if existing.Name() == n.Name() {
+			chain = append(chain[:i], chain[i+1:]...)
+			break
+		}
+	}
+	for i, existing := range chain {
+		if existing.Name() == before {
+			chain = append(chain[:i], append([]Notifier{n}, chain[i:]...)...)
+			return
+		}
+	}
+	chain = append(chain, n)
+}
+
+// Backends returns the fallback chain in order
+func Backends() []Notifier {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Notifier{}, chain...)
+}
+
+// Channels returns the remote channels configured in settings (webhook, Telegram, email, ntfy, Gotify).
+// Berbeda dengan fallback chain, setiap channel aktif selalu ikut dikirimi reminder.
+func Channels() []Notifier {
+	s := settings.Get()
+	var channels []Notifier
+	for _, cfg := range s.Webhooks {
+		channels = append(channels, NewWebhook(cfg))
+	}
+	telegramBot.Configure(s.Telegram)
+	channels = append(channels, telegramBot, NewEmail(s.Email), NewNtfy(s.Ntfy), NewGotify(s.Gotify))
+	return channels
+}
+
+// Get mencari backend atau channel berdasarkan nama
+func Get(name string) (Notifier, bool) {
+	for _, n := range append(Backends(), Channels()...) {
+		if n.Name() == name {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// Send mengirim n lewat backend pertama yang aktif, tersedia dan berhasil.
+// Semua percobaan dikembalikan, termasuk yang gagal sebelum fallback.
+func Send(n Notification) []Delivery {
+	var deliveries []Delivery
+	for _, backend := range Backends() {
+		if !settings.Get().NotifierEnabled(backend.Name()) || !backend.Available() {
+			continue
+		}
+
+		err := backend.Send(n)
+		deliveries = append(deliveries, Delivery{Backend: backend.Name(), Err: err})
+		if err == nil {
+			return deliveries
+		}
+		log.Printf("⚠️ Notification via %s failed, trying next backend: %v", backend.Name(), err)
+	}
+
+	if len(deliveries) == 0 {
+		deliveries = append(deliveries, Delivery{Backend: "desktop", Err: ErrNoBackend})
+	}
+	return deliveries
+}
+
+// Broadcast mengirim n ke semua channel yang aktif secara paralel, sehingga
+// satu server yang lambat tidak menunda channel lain
+func Broadcast(n Notification) []Delivery {
+	var active []Notifier
+	for _, channel := range Channels() {
+		if channel.Available() {
+			active = append(active, channel)
+		}
+	}
+	return sendAll(active, n)
+}
+
+// DigestReceiver adalah channel yang juga bisa menerima digest, misalnya email
+type DigestReceiver interface {
+	ReceivesDigest() bool
+}
+
+// BroadcastDigest mengirim digest n ke channel aktif yang meminta digest
+func BroadcastDigest(n Notification) []Delivery {
+	var active []Notifier
+	for _, channel := range Channels() {
+		if receiver, ok := channel.(DigestReceiver); ok && receiver.ReceivesDigest() && channel.Available() {
+			active = append(active, channel)
+		}
+	}
+	return sendAll(active, n)
+}
+
+// sendAll mengirim n ke semua channel secara paralel
+func sendAll(active []Notifier, n Notification) []Delivery {
+	deliveries := make([]Delivery, len(active))
+	var wg sync.WaitGroup
+	for i, channel := range active {
+		wg.Add(1)
+		go func(i int, channel Notifier) {
+			defer wg.Done()
+			err := channel.Send(n)
+			if err != nil {
+				log.Printf("⚠️ Notification via %s failed: %v", channel.Name(), err)
+			}
+			deliveries[i] = Delivery{Backend: channel.Name(), Err: err}
+		}(i, channel)
+	}
+	wg.Wait()
+	return deliveries
+}
+
+// SendTo mengirim n hanya lewat satu backend, misalnya untuk tombol test di Settings
+func SendTo(name string, n Notification) error {
+	backend, ok := Get(name)
+	if !ok {
+		return fmt.Errorf("unknown notification backend %q", name)
+	}
+	if !backend.Available() {
+		return fmt.Errorf("%s is not available on this system", name)
+	}
+	return backend.Send(n)
+}
+
+// Delivered returns true if one of the deliveries succeeded
+func Delivered(deliveries []Delivery) bool {
+	for _, d := range deliveries {
+		if d.Err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"bufio"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// NotifySend mengirim notifikasi lewat perintah notify-send (libnotify) di Linux
+type NotifySend struct{}
+
+func (NotifySend) Name() string { return "notify-send" }
+
+func (NotifySend) Available() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	_, err := exec.LookPath("notify-send")
+	return err == nil
+}
+
+func (NotifySend) Send(n Notification) error {
+	args := []string{"-u", n.Urgency.String(), "-a", "Anime Reminder"}
+	if n.Icon != "" {
+		args = append(args, "-i", n.Icon)
+	}
+
+	if len(n.Actions) == 0 || !notifySendSupportsActions() {
+		args = append(args, "-t", "5000", n.Title, n.Body)
+		return exec.Command("notify-send", args...).Run()
+	}
+
+	args = append(args, "--wait")
+	for _, action := range n.Actions {
+		args = append(args, "--action="+action.Key+"="+action.Label)
+	}
+	args = append(args, n.Title, n.Body)
+
+	cmd := exec.Command("notify-send", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// notify-send --wait mencetak key aksi yang diklik lalu keluar
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			if key := strings.TrimSpace(scanner.Text()); key != "" && n.OnAction != nil {
+				n.OnAction(key)
+			}
+		}
+		cmd.Wait()
+	}()
+	return nil
+}
+
+var (
+	notifySendActionsOnce sync.Once
+	notifySendActions     bool
+)
+
+// notifySendSupportsActions mengecek apakah notify-send cukup baru untuk --action (libnotify >= 0.7.10)
+func notifySendSupportsActions() bool {
+	notifySendActionsOnce.Do(func() {
+		out, err := exec.Command("notify-send", "--help").CombinedOutput()
+		notifySendActions = err == nil && strings.Contains(string(out), "--action")
+	})
+	return notifySendActions
+}
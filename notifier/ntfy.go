@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"net/http"
+)
+
+// Ntfy mengirim notifikasi ke topic ntfy (https://ntfy.sh atau server sendiri)
+// lewat JSON publishing, supaya judul dengan emoji tidak perlu di-encode di header
+type Ntfy struct {
+	Config models.Ntfy
+	// Client nil berarti http.Client dengan pushTimeout
+	Client *http.Client
+}
+
+// NewNtfy membuat backend ntfy dari settings
+func NewNtfy(cfg models.Ntfy) *Ntfy {
+	return &Ntfy{Config: cfg}
+}
+
+func (n *Ntfy) Name() string { return "ntfy" }
+
+func (n *Ntfy) Available() bool { return n.Config.Configured() }
+
+// ntfyMessage adalah body JSON publish ntfy
+type ntfyMessage struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title,omitempty"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Click    string   `json:"click,omitempty"`
+}
+
+func (n *Ntfy) Send(notification Notification) error {
+	body, err := json.Marshal(ntfyMessage{
+		Topic:    n.Config.Topic,
+		Title:    notification.Title,
+		Message:  notification.Body,
+		Priority: pushPriority(n.Config.PriorityMap(), notification.Urgency),
+		Tags:     pushTags(notification, n.Config.Tags),
+		Click:    pushClickURL(notification, n.Config.ClickURL),
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{}
+	if n.Config.Token != "" {
+		headers["Authorization"] = "Bearer " + n.Config.Token
+	}
+	return postJSON(pushClient(n.Client), http.MethodPost, n.Config.Server(), headers, body)
+}
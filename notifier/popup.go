@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"errors"
+	"sync"
+)
+
+// Popup menampilkan notifikasi sebagai jendela di dalam aplikasi. Ini fallback
+// terakhir jika tidak ada notifikasi desktop; tampilannya diisi UI lewat SetPopupHandler.
+type Popup struct {
+	mu      sync.RWMutex
+	handler func(n Notification) error
+}
+
+var popup = &Popup{}
+
+// SetPopupHandler mengatur fungsi UI yang menampilkan popup
+func SetPopupHandler(fn func(n Notification) error) {
+	popup.mu.Lock()
+	defer popup.mu.Unlock()
+	popup.handler = fn
+}
+
+func (p *Popup) Name() string { return "popup" }
+
+func (p *Popup) Available() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.handler != nil
+}
+
+func (p *Popup) Send(n Notification) error {
+	p.mu.RLock()
+	handler := p.handler
+	p.mu.RUnlock()
+	if handler == nil {
+		return errors.New("in-app popup is not available")
+	}
+	return handler(n)
+}
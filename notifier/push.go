@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pushTimeout adalah batas waktu satu request ke server push (ntfy, Gotify)
+const pushTimeout = 15 * time.Second
+
+// pushEmoji adalah emoji untuk tag yang umum dipakai, ntfy menampilkannya
+// sendiri, Gotify tidak punya tag sehingga emoji ditaruh di depan judul
+var pushEmoji = map[string]string{
+	"tv":               "📺",
+	"alarm_clock":      "⏰",
+	"zzz":              "💤",
+	"calendar":         "📅",
+	"bell":             "🔔",
+	"warning":          "⚠️",
+	"star":             "⭐",
+	"popcorn":          "🍿",
+	"clapper":          "🎬",
+	"white_check_mark": "✅",
+}
+
+// pushPriority memetakan urgency ke prioritas server
+func pushPriority(p models.PushPriorities, u Urgency) int {
+	switch u {
+	case UrgencyLow:
+		return p.Low
+	case UrgencyCritical:
+		return p.Critical
+	default:
+		return p.Normal
+	}
+}
+
+// pushClickURL: stream link anime, atau URL default dari pengaturan
+func pushClickURL(n Notification, fallback string) string {
+	if n.URL != "" {
+		return n.URL
+	}
+	return fallback
+}
+
+// pushTags menggabungkan tag notifikasi dengan tag tambahan dari pengaturan
+func pushTags(n Notification, extra []string) []string {
+	tags := append([]string{}, n.Tags...)
+	for _, tag := range extra {
+		if !containsTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// emojiPrefix menaruh emoji dari tag yang dikenal di depan judul, seperti tampilan ntfy
+func emojiPrefix(tags []string, title string) string {
+	var emojis []string
+	for _, tag := range tags {
+		if emoji, ok := pushEmoji[tag]; ok && !strings.HasPrefix(title, emoji) {
+			emojis = append(emojis, emoji)
+		}
+	}
+	if len(emojis) == 0 {
+		return title
+	}
+	return strings.Join(emojis, "") + " " + title
+}
+
+// pushClient dipakai jika backend tidak punya Client sendiri
+func pushClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: pushTimeout}
+}
@@ -0,0 +1,184 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNtfySend(t *testing.T) {
+	tests := []struct {
+		name   string
+		config models.Ntfy
+		n      Notification
+		want   ntfyMessage
+		// wantAuth adalah header Authorization yang diharapkan, kosong = tidak dikirim
+		wantAuth string
+	}{
+		{
+			name:   "default priorities and click URL from settings",
+			config: models.Ntfy{Topic: "anime", Tags: []string{"tv"}, ClickURL: "https://example.com/schedule"},
+			n:      Notification{Title: "🎬 Anime Time!", Body: "Frieren is airing now!", Urgency: UrgencyCritical, Tags: []string{"clapper"}},
+			want: ntfyMessage{
+				Topic: "anime", Title: "🎬 Anime Time!", Message: "Frieren is airing now!",
+				Priority: 5, Tags: []string{"clapper", "tv"}, Click: "https://example.com/schedule",
+			},
+		},
+		{
+			name: "stream link and custom priorities",
+			config: models.Ntfy{
+				Topic:      "anime",
+				Token:      "tk_secret",
+				Priorities: models.PushPriorities{Low: 1},
+				ClickURL:   "https://example.com/schedule",
+			},
+			n: Notification{Title: "⏰ Starting Soon", Body: "Frieren starts in 15 minutes", Urgency: UrgencyLow, URL: "https://stream.example.com/frieren", Tags: []string{"alarm_clock"}},
+			want: ntfyMessage{
+				Topic: "anime", Title: "⏰ Starting Soon", Message: "Frieren starts in 15 minutes",
+				Priority: 1, Tags: []string{"alarm_clock"}, Click: "https://stream.example.com/frieren",
+			},
+			wantAuth: "Bearer tk_secret",
+		},
+		{
+			name:   "normal urgency without tags",
+			config: models.Ntfy{Topic: "anime"},
+			n:      Notification{Title: "📅 Today's anime", Body: "Nothing airs today", Urgency: UrgencyNormal},
+			want:   ntfyMessage{Topic: "anime", Title: "📅 Today's anime", Message: "Nothing airs today", Priority: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeHTTPServer(t)
+			tt.config.Enabled = true
+			tt.config.ServerURL = server.URL + "/"
+			backend := NewNtfy(tt.config)
+			if err := backend.Send(tt.n); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			requests := server.recorded()
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			req := requests[0]
+			// Publish JSON dikirim ke root server, topic ada di body
+			if req.Method != http.MethodPost || req.Path != "/" {
+				t.Errorf("request = %s %s, want POST /", req.Method, req.Path)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantAuth)
+			}
+
+			var got ntfyMessage
+			if err := json.Unmarshal(req.Body, &got); err != nil {
+				t.Fatalf("body is not valid JSON: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("message = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGotifySend(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       models.Gotify
+		n            Notification
+		wantTitle    string
+		wantPriority int
+		// wantClick kosong berarti tidak ada extras
+		wantClick string
+	}{
+		{
+			name:         "emoji prefix from tags",
+			config:       models.Gotify{Tags: []string{"tv"}},
+			n:            Notification{Title: "Anime Time!", Body: "Frieren is airing now!", Urgency: UrgencyCritical, Tags: []string{"clapper"}},
+			wantTitle:    "🎬📺 Anime Time!",
+			wantPriority: 8,
+		},
+		{
+			name:         "emoji already in title",
+			config:       models.Gotify{ClickURL: "https://example.com/schedule"},
+			n:            Notification{Title: "💤 Anime Time!", Body: "Snooze is over", Urgency: UrgencyNormal, Tags: []string{"zzz"}},
+			wantTitle:    "💤 Anime Time!",
+			wantPriority: 5,
+			wantClick:    "https://example.com/schedule",
+		},
+		{
+			name:         "stream link and custom priority",
+			config:       models.Gotify{Priorities: models.PushPriorities{Low: 1}, ClickURL: "https://example.com/schedule"},
+			n:            Notification{Title: "Starting Soon", Body: "Frieren starts in 15 minutes", Urgency: UrgencyLow, URL: "https://stream.example.com/frieren", Tags: []string{"alarm_clock"}},
+			wantTitle:    "⏰ Starting Soon",
+			wantPriority: 1,
+			wantClick:    "https://stream.example.com/frieren",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeHTTPServer(t)
+			tt.config.Enabled = true
+			tt.config.ServerURL = server.URL + "/gotify/"
+			tt.config.AppToken = "AbCdEf"
+			backend := NewGotify(tt.config)
+			if err := backend.Send(tt.n); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			requests := server.recorded()
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			req := requests[0]
+			if req.Method != http.MethodPost || req.Path != "/gotify/message" {
+				t.Errorf("request = %s %s, want POST /gotify/message", req.Method, req.Path)
+			}
+			if got := req.Header.Get("X-Gotify-Key"); got != "AbCdEf" {
+				t.Errorf("X-Gotify-Key = %q", got)
+			}
+
+			var got struct {
+				Title    string `json:"title"`
+				Message  string `json:"message"`
+				Priority int    `json:"priority"`
+				Extras   map[string]struct {
+					Click struct {
+						URL string `json:"url"`
+					} `json:"click"`
+				} `json:"extras"`
+			}
+			if err := json.Unmarshal(req.Body, &got); err != nil {
+				t.Fatalf("body is not valid JSON: %v", err)
+			}
+			if got.Title != tt.wantTitle || got.Message != tt.n.Body || got.Priority != tt.wantPriority {
+				t.Errorf("message = %q / %q / %d, want %q / %q / %d",
+					got.Title, got.Message, got.Priority, tt.wantTitle, tt.n.Body, tt.wantPriority)
+			}
+			click, ok := got.Extras["client::notification"]
+			if tt.wantClick == "" {
+				if got.Extras != nil {
+					t.Errorf("extras = %s, want none", req.Body)
+				}
+			} else if !ok || click.Click.URL != tt.wantClick {
+				t.Errorf("click URL = %q, want %q", click.Click.URL, tt.wantClick)
+			}
+		})
+	}
+}
+
+func TestPushServerError(t *testing.T) {
+	server := newFakeHTTPServer(t, http.StatusUnauthorized)
+
+	ntfy := NewNtfy(models.Ntfy{Enabled: true, ServerURL: server.URL, Topic: "anime"})
+	if err := ntfy.Send(Notification{Title: "🎬 Anime Time!"}); err == nil {
+		t.Error("ntfy Send ignored a 401 response")
+	}
+	gotify := NewGotify(models.Gotify{Enabled: true, ServerURL: server.URL, AppToken: "wrong"})
+	if err := gotify.Send(Notification{Title: "🎬 Anime Time!"}); err == nil {
+		t.Error("Gotify Send ignored a 401 response")
+	}
+}
@@ -0,0 +1,257 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// telegramPollTimeout adalah lama long-poll getUpdates di server Telegram
+	telegramPollTimeout = 30 * time.Second
+	// telegramCallbackTTL: tombol yang tidak diklik selama ini tidak ditunggu lagi
+	telegramCallbackTTL = 12 * time.Hour
+	// telegramRetryDelay adalah jeda setelah getUpdates gagal
+	telegramRetryDelay = 5 * time.Second
+)
+
+// Telegram mengirim notifikasi ke satu chat lewat Bot API. Tombol notifikasi
+// menjadi inline keyboard; kliknya diambil dengan long-polling getUpdates
+// selama masih ada pesan yang menunggu.
+type Telegram struct {
+	// Client nil berarti http.Client dengan timeout di atas telegramPollTimeout
+	Client *http.Client
+
+	mu      sync.Mutex
+	config  models.Telegram
+	pending map[int64]telegramPending // message_id -> callback
+	polling bool
+	offset  int64
+}
+
+// telegramPending adalah pesan dengan tombol yang belum diklik
+type telegramPending struct {
+	onAction func(key string)
+	labels   map[string]string
+	sentAt   time.Time
+}
+
+// telegramBot dipakai Channels, supaya callback tetap ada walaupun settings berubah
+var telegramBot = NewTelegram(models.Telegram{})
+
+// NewTelegram membuat bot dengan pengaturan cfg
+func NewTelegram(cfg models.Telegram) *Telegram {
+	return &Telegram{config: cfg, pending: make(map[int64]telegramPending)}
+}
+
+// Configure mengganti pengaturan bot, pesan yang menunggu tetap dilayani
+func (t *Telegram) Configure(cfg models.Telegram) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cfg.BotToken != t.config.BotToken {
+		// Update id berlaku per bot
+		t.offset = 0
+	}
+	t.config = cfg
+}
+
+func (t *Telegram) Name() string { return "telegram" }
+
+func (t *Telegram) Available() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.config.Configured()
+}
+
+// telegramButton adalah InlineKeyboardButton
+type telegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+type telegramMessage struct {
+	MessageId int64 `json:"message_id"`
+}
+
+func (t *Telegram) Send(n Notification) error {
+	t.mu.Lock()
+	cfg := t.config
+	t.mu.Unlock()
+
+	params := map[string]interface{}{
+		"chat_id":    cfg.ChatId,
+		"text":       fmt.Sprintf("<b>%s</b>\n%s", html.EscapeString(n.Title), html.EscapeString(n.Body)),
+		"parse_mode": "HTML",
+	}
+
+	// "Open" hanya berarti di desktop, tombol lain dikirim sebagai inline keyboard
+	var row []telegramButton
+	labels := make(map[string]string)
+	for _, action := range n.Actions {
+		if action.Key == ActionOpen {
+			continue
+		}
+		row = append(row, telegramButton{Text: action.Label, CallbackData: action.Key})
+		labels[action.Key] = action.Label
+	}
+	withButtons := len(row) > 0 && n.OnAction != nil
+	if withButtons {
+		params["reply_markup"] = map[string]interface{}{"inline_keyboard": [][]telegramButton{row}}
+	}
+
+	var message telegramMessage
+	if err := t.call(cfg, "sendMessage", params, &message); err != nil {
+		return err
+	}
+
+	if withButtons {
+		t.mu.Lock()
+		t.pending[message.MessageId] = telegramPending{onAction: n.OnAction, labels: labels, sentAt: time.Now()}
+		start := !t.polling
+		t.polling = true
+		t.mu.Unlock()
+		if start {
+			go t.poll()
+		}
+	}
+	return nil
+}
+
+// telegramUpdate hanya berisi callback_query, update lain diabaikan
+type telegramUpdate struct {
+	UpdateId      int64 `json:"update_id"`
+	CallbackQuery *struct {
+		Id      string           `json:"id"`
+		Data    string           `json:"data"`
+		Message *telegramMessage `json:"message"`
+	} `json:"callback_query"`
+}
+
+// poll mengambil klik tombol sampai tidak ada lagi pesan yang menunggu
+func (t *Telegram) poll() {
+	for {
+		t.mu.Lock()
+		for id, p := range t.pending {
+			if time.Since(p.sentAt) > telegramCallbackTTL {
+				delete(t.pending, id)
+			}
+		}
+		if len(t.pending) == 0 || !t.config.Configured() {
+			t.polling = false
+			t.mu.Unlock()
+			return
+		}
+		cfg := t.config
+		offset := t.offset
+		t.mu.Unlock()
+
+		var updates []telegramUpdate
+		err := t.call(cfg, "getUpdates", map[string]interface{}{
+			"offset":          offset,
+			"timeout":         int(telegramPollTimeout / time.Second),
+			"allowed_updates": []string{"callback_query"},
+		}, &updates)
+		if err != nil {
+			log.Printf("⚠️ Telegram getUpdates failed: %v", err)
+			time.Sleep(telegramRetryDelay)
+			continue
+		}
+
+		for _, update := range updates {
+			t.mu.Lock()
+			if update.UpdateId >= t.offset {
+				t.offset = update.UpdateId + 1
+			}
+			t.mu.Unlock()
+			if update.CallbackQuery != nil {
+				t.handleCallback(cfg, update)
+			}
+		}
+	}
+}
+
+// handleCallback menjalankan aksi tombol lalu menghapus keyboard dari pesan
+func (t *Telegram) handleCallback(cfg models.Telegram, update telegramUpdate) {
+	query := update.CallbackQuery
+
+	var messageID int64
+	if query.Message != nil {
+		messageID = query.Message.MessageId
+	}
+	t.mu.Lock()
+	pending, ok := t.pending[messageID]
+	delete(t.pending, messageID)
+	t.mu.Unlock()
+
+	answer := "This reminder has expired"
+	if ok {
+		answer = "✅ " + pending.labels[query.Data]
+		log.Printf("📨 Telegram action: %s", query.Data)
+		pending.onAction(query.Data)
+	}
+
+	if err := t.call(cfg, "answerCallbackQuery", map[string]interface{}{
+		"callback_query_id": query.Id,
+		"text":              answer,
+	}, nil); err != nil {
+		log.Printf("⚠️ Telegram answerCallbackQuery failed: %v", err)
+	}
+	if messageID != 0 {
+		if err := t.call(cfg, "editMessageReplyMarkup", map[string]interface{}{
+			"chat_id":      cfg.ChatId,
+			"message_id":   messageID,
+			"reply_markup": map[string]interface{}{"inline_keyboard": [][]telegramButton{}},
+		}, nil); err != nil {
+			log.Printf("⚠️ Telegram editMessageReplyMarkup failed: %v", err)
+		}
+	}
+}
+
+// telegramResponse adalah amplop semua response Bot API
+type telegramResponse struct {
+	Ok          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+// call memanggil method Bot API dengan params JSON dan mengisi result (boleh nil)
+func (t *Telegram) call(cfg models.Telegram, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: telegramPollTimeout + 15*time.Second}
+	}
+	url := fmt.Sprintf("%s/bot%s/%s", cfg.APIURL(), cfg.BotToken, method)
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// Error dari net/http menyertakan URL, jangan bocorkan token ke log
+		if urlErr, ok := err.(*neturl.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("telegram %s: server returned %d", method, resp.StatusCode)
+	}
+	if !envelope.Ok {
+		return fmt.Errorf("telegram %s: %s", method, envelope.Description)
+	}
+	if result != nil {
+		return json.Unmarshal(envelope.Result, result)
+	}
+	return nil
+}
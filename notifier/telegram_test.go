@@ -0,0 +1,238 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testBotToken = "123456:secret"
+
+// telegramCall adalah satu panggilan method Bot API yang diterima server palsu
+type telegramCall struct {
+	Method string
+	Params map[string]interface{}
+}
+
+// fakeBotAPI adalah Bot API palsu. getUpdates menunggu batch dari updates
+// (atau mengembalikan daftar kosong setelah jeda singkat, seperti long-poll).
+type fakeBotAPI struct {
+	*httptest.Server
+	updates chan []map[string]interface{}
+
+	mu            sync.Mutex
+	calls         []telegramCall
+	nextMessageID int64
+	// offsets adalah offset getUpdates yang menerima setiap batch dari updates
+	offsets []int64
+}
+
+func newFakeBotAPI(t *testing.T) *fakeBotAPI {
+	t.Helper()
+	f := &fakeBotAPI{updates: make(chan []map[string]interface{}), nextMessageID: 100}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBotAPI) handle(w http.ResponseWriter, r *http.Request) {
+	prefix := "/bot" + testBotToken + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "description": "Unauthorized"})
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, prefix)
+
+	var params map[string]interface{}
+	body, _ := io.ReadAll(r.Body)
+	json.Unmarshal(body, &params)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, telegramCall{Method: method, Params: params})
+	f.mu.Unlock()
+
+	var result interface{} = true
+	switch method {
+	case "sendMessage":
+		f.mu.Lock()
+		result = map[string]interface{}{"message_id": f.nextMessageID}
+		f.nextMessageID++
+		f.mu.Unlock()
+	case "getUpdates":
+		select {
+		case batch := <-f.updates:
+			offset, _ := params["offset"].(float64)
+			f.mu.Lock()
+			f.offsets = append(f.offsets, int64(offset))
+			f.mu.Unlock()
+			result = batch
+		case <-time.After(50 * time.Millisecond):
+			result = []interface{}{}
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+// callsTo mengembalikan semua panggilan method
+func (f *fakeBotAPI) callsTo(method string) []telegramCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []telegramCall
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// callbackUpdate membuat update callback_query untuk tombol key di pesan messageID
+func callbackUpdate(updateID, messageID int64, key string) map[string]interface{} {
+	return map[string]interface{}{
+		"update_id": updateID,
+		"callback_query": map[string]interface{}{
+			"id":      fmt.Sprintf("query-%d", updateID),
+			"data":    key,
+			"message": map[string]interface{}{"message_id": messageID},
+		},
+	}
+}
+
+func newTestTelegram(server *fakeBotAPI) *Telegram {
+	bot := NewTelegram(models.Telegram{Enabled: true, BotToken: testBotToken, ChatId: "42", BaseURL: server.URL + "/"})
+	bot.Client = server.Client()
+	return bot
+}
+
+func waitForKey(t *testing.T, keys <-chan string, want string) {
+	t.Helper()
+	select {
+	case key := <-keys:
+		if key != want {
+			t.Errorf("OnAction(%q), want %q", key, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("OnAction not called for %q", want)
+	}
+}
+
+func TestTelegramSendMessage(t *testing.T) {
+	server := newFakeBotAPI(t)
+	bot := newTestTelegram(server)
+
+	n := Notification{Title: "🎬 Anime <Time>", Body: "Frieren & friends"}
+	if err := bot.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	calls := server.callsTo("sendMessage")
+	if len(calls) != 1 {
+		t.Fatalf("got %d sendMessage calls, want 1", len(calls))
+	}
+	params := calls[0].Params
+	if params["chat_id"] != "42" || params["parse_mode"] != "HTML" {
+		t.Errorf("params = %v", params)
+	}
+	if want := "<b>🎬 Anime &lt;Time&gt;</b>\nFrieren &amp; friends"; params["text"] != want {
+		t.Errorf("text = %q, want %q", params["text"], want)
+	}
+	if _, ok := params["reply_markup"]; ok {
+		t.Errorf("notification without actions sent a keyboard: %v", params["reply_markup"])
+	}
+
+	// Tanpa tombol tidak ada yang perlu ditunggu
+	time.Sleep(100 * time.Millisecond)
+	if got := len(server.callsTo("getUpdates")); got != 0 {
+		t.Errorf("getUpdates called %d times without pending buttons", got)
+	}
+}
+
+func TestTelegramCallbacks(t *testing.T) {
+	server := newFakeBotAPI(t)
+	bot := newTestTelegram(server)
+
+	keys := make(chan string, 4)
+	n := Notification{
+		Title:    "🎬 Anime Reminder",
+		Body:     "Frieren is airing now!",
+		Actions:  []Action{{Key: "snooze-10", Label: "Snooze 10 min"}, {Key: ActionOpen, Label: "Open"}, {Key: "dismiss", Label: "Dismiss"}},
+		OnAction: func(key string) { keys <- key },
+	}
+	if err := bot.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := bot.Send(n); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	// Tombol Open tidak berguna di Telegram
+	markup, _ := json.Marshal(server.callsTo("sendMessage")[0].Params["reply_markup"])
+	want := `{"inline_keyboard":[[{"callback_data":"snooze-10","text":"Snooze 10 min"},{"callback_data":"dismiss","text":"Dismiss"}]]}`
+	if string(markup) != want {
+		t.Errorf("reply_markup = %s, want %s", markup, want)
+	}
+
+	// Klik di pesan pertama; pesan kedua masih menunggu jadi polling berlanjut
+	server.updates <- []map[string]interface{}{callbackUpdate(41, 100, "snooze-10")}
+	waitForKey(t, keys, "snooze-10")
+	server.updates <- []map[string]interface{}{callbackUpdate(42, 101, "dismiss")}
+	waitForKey(t, keys, "dismiss")
+
+	// Polling berhenti setelah semua pesan dijawab
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		bot.mu.Lock()
+		polling := bot.polling
+		bot.mu.Unlock()
+		if !polling {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("polling did not stop after the last pending message")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	polls := len(server.callsTo("getUpdates"))
+	time.Sleep(150 * time.Millisecond)
+	if got := len(server.callsTo("getUpdates")); got != polls {
+		t.Errorf("getUpdates called %d more times after polling stopped", got-polls)
+	}
+
+	server.mu.Lock()
+	offsets := append([]int64(nil), server.offsets...)
+	server.mu.Unlock()
+	if len(offsets) != 2 || offsets[1] != 42 {
+		t.Errorf("getUpdates offsets = %v, want the second batch requested with offset 42", offsets)
+	}
+
+	answers := server.callsTo("answerCallbackQuery")
+	if len(answers) != 2 || answers[0].Params["callback_query_id"] != "query-41" || answers[0].Params["text"] != "✅ Snooze 10 min" {
+		t.Errorf("answerCallbackQuery calls = %v", answers)
+	}
+	edits := server.callsTo("editMessageReplyMarkup")
+	if len(edits) != 2 || edits[0].Params["message_id"] != float64(100) || edits[1].Params["message_id"] != float64(101) {
+		t.Fatalf("editMessageReplyMarkup calls = %v", edits)
+	}
+	cleared, _ := json.Marshal(edits[0].Params["reply_markup"])
+	if string(cleared) != `{"inline_keyboard":[]}` {
+		t.Errorf("keyboard not removed: %s", cleared)
+	}
+}
+
+func TestTelegramAPIError(t *testing.T) {
+	server := newFakeBotAPI(t)
+	bot := NewTelegram(models.Telegram{Enabled: true, BotToken: "wrong", ChatId: "42", BaseURL: server.URL})
+	bot.Client = server.Client()
+
+	err := bot.Send(Notification{Title: "🎬 Anime Reminder"})
+	if err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Errorf("Send error = %v, want the API description", err)
+	}
+}
@@ -0,0 +1,123 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// webhookBackoff adalah jeda sebelum percobaan ulang pertama, dikali nomor percobaan
+var webhookBackoff = 2 * time.Second
+
+// webhookTxn membuat TxnId unik dalam satu proses
+var webhookTxn atomic.Uint64
+
+// Webhook mengirim notifikasi sebagai HTTP request JSON ke satu models.Webhook
+type Webhook struct {
+	Config models.Webhook
+	// Client nil berarti http.Client dengan timeout dari Config
+	Client *http.Client
+}
+
+// NewWebhook membuat backend untuk satu webhook dari settings
+func NewWebhook(cfg models.Webhook) *Webhook {
+	return &Webhook{Config: cfg}
+}
+
+func (w *Webhook) Name() string { return "webhook:" + w.Config.Name }
+
+func (w *Webhook) Available() bool {
+	return w.Config.Enabled && w.Config.URL != ""
+}
+
+// httpStatusError adalah response non-2xx dari server
+type httpStatusError struct {
+	Code int
+	Body string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("server returned %d", e.Code)
+	}
+	return fmt.Sprintf("server returned %d: %s", e.Code, e.Body)
+}
+
+// retryable: error jaringan, 429 dan 5xx dicoba lagi, 4xx lainnya tidak akan berubah
+func retryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= 500
+	}
+	return true
+}
+
+func (w *Webhook) Send(n Notification) error {
+	msg := models.WebhookMessage{
+		Title:      n.Title,
+		Body:       n.Body,
+		AnimeTitle: n.AnimeTitle,
+		Episode:    n.Episode,
+		Urgency:    n.Urgency.String(),
+		TxnId:      fmt.Sprintf("anime-reminder-%d-%d", time.Now().UnixNano(), webhookTxn.Add(1)),
+	}
+	if !n.AirAt.IsZero() {
+		msg.AirTime = n.AirAt.In(time.Local).Format("2006-01-02 15:04")
+	}
+	url, body, err := w.Config.Render(msg)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = w.post(url, body)
+		if err == nil || attempt >= w.Config.Retries || !retryable(err) {
+			return err
+		}
+		log.Printf("⚠️ Webhook %s failed (attempt %d), retrying: %v", w.Config.Name, attempt+1, err)
+		time.Sleep(webhookBackoff * time.Duration(attempt+1))
+	}
+}
+
+func (w *Webhook) post(url, body string) error {
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: w.Config.Timeout()}
+	}
+	return postJSON(client, w.Config.HTTPMethod(), url, w.Config.Headers, []byte(body))
+}
+
+// postJSON mengirim body JSON dan mengembalikan httpStatusError untuk response non-2xx.
+// Dipakai semua backend HTTP (webhook, ntfy, Gotify).
+func postJSON(client *http.Client, method, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "AnimeReminder")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Potongan body membantu menjelaskan error (token salah, payload ditolak, ...)
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return &httpStatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(snippet))}
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
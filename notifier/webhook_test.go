@@ -0,0 +1,243 @@
+package notifier
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedRequest adalah satu request yang diterima server palsu
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// fakeHTTPServer mencatat semua request dan menjawab dengan status dari statuses
+// secara berurutan (status terakhir dipakai untuk request berikutnya)
+type fakeHTTPServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []recordedRequest
+}
+
+func newFakeHTTPServer(t *testing.T, statuses ...int) *fakeHTTPServer {
+	t.Helper()
+	f := &fakeHTTPServer{statuses: statuses}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone(), Body: body})
+		status := http.StatusOK
+		if len(f.statuses) > 0 {
+			status = f.statuses[0]
+			if len(f.statuses) > 1 {
+				f.statuses = f.statuses[1:]
+			}
+		}
+		f.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeHTTPServer) recorded() []recordedRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]recordedRequest(nil), f.requests...)
+}
+
+// noWebhookBackoff mematikan jeda retry selama test
+func noWebhookBackoff(t *testing.T) {
+	original := webhookBackoff
+	webhookBackoff = 0
+	t.Cleanup(func() { webhookBackoff = original })
+}
+
+// trickyNotification berisi tanda kutip dan baris baru yang harus di-escape di JSON
+var trickyNotification = Notification{
+	Title:      `🎬 "Frieren" Time!`,
+	Body:       "Sousou no \"Frieren\" is airing now!\nEpisode 7/12",
+	AnimeTitle: `Sousou no "Frieren"`,
+	Episode:    "Episode 7/12",
+	AirAt:      time.Date(2026, 10, 17, 23, 0, 0, 0, time.Local),
+}
+
+func TestWebhookPresetsSendValidJSON(t *testing.T) {
+	tests := []struct {
+		preset     models.WebhookPreset
+		wantMethod string
+		// check memeriksa body yang sudah di-decode
+		check func(t *testing.T, payload map[string]interface{})
+	}{
+		{
+			preset:     models.WebhookDiscord,
+			wantMethod: http.MethodPost,
+			check: func(t *testing.T, payload map[string]interface{}) {
+				embeds, _ := payload["embeds"].([]interface{})
+				if len(embeds) != 1 {
+					t.Fatalf("embeds = %v, want one embed", payload["embeds"])
+				}
+				embed, _ := embeds[0].(map[string]interface{})
+				if embed["title"] != trickyNotification.Title || embed["description"] != trickyNotification.Body {
+					t.Errorf("embed = %v", embed)
+				}
+			},
+		},
+		{
+			preset:     models.WebhookSlack,
+			wantMethod: http.MethodPost,
+			check: func(t *testing.T, payload map[string]interface{}) {
+				want := "*" + trickyNotification.Title + "*\n" + trickyNotification.Body
+				if payload["text"] != want {
+					t.Errorf("text = %q, want %q", payload["text"], want)
+				}
+			},
+		},
+		{
+			preset:     models.WebhookMatrix,
+			wantMethod: http.MethodPut,
+			check: func(t *testing.T, payload map[string]interface{}) {
+				want := trickyNotification.Title + "\n" + trickyNotification.Body
+				if payload["msgtype"] != "m.text" || payload["body"] != want {
+					t.Errorf("payload = %v", payload)
+				}
+			},
+		},
+		{
+			preset:     models.WebhookCustom,
+			wantMethod: http.MethodPost,
+			check: func(t *testing.T, payload map[string]interface{}) {
+				if payload["title"] != trickyNotification.Title || payload["body"] != trickyNotification.Body ||
+					payload["anime"] != trickyNotification.AnimeTitle || payload["episode"] != trickyNotification.Episode ||
+					payload["air_time"] != "2026-10-17 23:00" {
+					t.Errorf("payload = %v", payload)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.preset), func(t *testing.T) {
+			server := newFakeHTTPServer(t)
+			w := NewWebhook(models.Webhook{
+				Name:    "team",
+				Enabled: true,
+				Preset:  tt.preset,
+				URL:     server.URL + "/hook/{{.TxnId}}",
+				Headers: map[string]string{"Authorization": "Bearer secret", "X-Custom": "anime"},
+			})
+			if err := w.Send(trickyNotification); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			requests := server.recorded()
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			req := requests[0]
+			if req.Method != tt.wantMethod {
+				t.Errorf("method = %s, want %s", req.Method, tt.wantMethod)
+			}
+			if got := req.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			if req.Header.Get("Authorization") != "Bearer secret" || req.Header.Get("X-Custom") != "anime" {
+				t.Errorf("custom headers not sent: %v", req.Header)
+			}
+			if !strings.HasPrefix(req.Path, "/hook/anime-reminder-") {
+				t.Errorf("path = %q, want the TxnId rendered into the URL", req.Path)
+			}
+
+			var payload map[string]interface{}
+			if err := json.Unmarshal(req.Body, &payload); err != nil {
+				t.Fatalf("body is not valid JSON: %v\n%s", err, req.Body)
+			}
+			tt.check(t, payload)
+		})
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	noWebhookBackoff(t)
+
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		wantErr  bool
+		wantSent int
+	}{
+		{name: "5xx is retried", statuses: []int{500, 502, 200}, retries: 3, wantSent: 3},
+		{name: "429 is retried", statuses: []int{429, 200}, retries: 3, wantSent: 2},
+		{name: "other 4xx is not retried", statuses: []int{400}, retries: 3, wantErr: true, wantSent: 1},
+		{name: "404 is not retried", statuses: []int{404}, retries: 3, wantErr: true, wantSent: 1},
+		{name: "gives up after retries", statuses: []int{503}, retries: 2, wantErr: true, wantSent: 3},
+		{name: "no retries configured", statuses: []int{500}, retries: 0, wantErr: true, wantSent: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeHTTPServer(t, tt.statuses...)
+			w := NewWebhook(models.Webhook{Name: "team", Enabled: true, Preset: models.WebhookDiscord, URL: server.URL, Retries: tt.retries})
+
+			err := w.Send(trickyNotification)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Send error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(server.recorded()); got != tt.wantSent {
+				t.Errorf("sent %d requests, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestWebhookMatrixKeepsTxnIdAcrossRetries(t *testing.T) {
+	noWebhookBackoff(t)
+
+	server := newFakeHTTPServer(t, 500, 503, 200)
+	w := NewWebhook(models.Webhook{
+		Name:    "room",
+		Enabled: true,
+		Preset:  models.WebhookMatrix,
+		URL:     server.URL + "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/{{.TxnId}}",
+		Retries: 3,
+	})
+	if err := w.Send(trickyNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	requests := server.recorded()
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+	for _, req := range requests {
+		if req.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", req.Method)
+		}
+		// Retry harus memakai TxnId yang sama supaya Matrix tidak mengirim pesan dua kali
+		if req.Path != requests[0].Path {
+			t.Errorf("retry path = %q, want %q", req.Path, requests[0].Path)
+		}
+	}
+
+	// Pesan berikutnya mendapat TxnId baru
+	if err := w.Send(trickyNotification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if next := server.recorded()[3]; next.Path == requests[0].Path {
+		t.Errorf("second message reused TxnId path %q", next.Path)
+	}
+}
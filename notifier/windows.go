@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// WindowsToast mengirim toast notification Windows 10/11 lewat PowerShell
+type WindowsToast struct{}
+
+func (WindowsToast) Name() string { return "windows-toast" }
+
+func (WindowsToast) Available() bool { return runtime.GOOS == "windows" }
+
+func (WindowsToast) Send(n Notification) error {
+	return toastCommand(n).Run()
+}
+
+// toastEnv adalah environment variable yang membawa XML toast ke PowerShell
+const toastEnv = "ANIME_REMINDER_TOAST"
+
+// toastScript tidak pernah berisi teks dari notifikasi. XML dibaca dari toastEnv,
+// jadi judul dengan $(...) atau backtick tidak bisa dieksekusi PowerShell.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.UI.Notifications.ToastNotification, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+
+$APP_ID = 'AnimeReminder'
+
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml($env:` + toastEnv + `)
+$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($APP_ID).Show($toast)
+`
+
+// toastCommand membuat perintah PowerShell yang menampilkan n
+func toastCommand(n Notification) *exec.Cmd {
+	cmd := exec.Command("powershell", "-NoProfile", "-Command", toastScript)
+	cmd.Env = append(os.Environ(), toastEnv+"="+toastXML(n))
+	return cmd
+}
+
+// toastXML membuat isi toast notification
+func toastXML(n Notification) string {
+	return fmt.Sprintf(`<toast scenario="%s">
+    <visual>
+        <binding template="ToastGeneric">
+            <text>%s</text>
+            <text>%s</text>%s
+        </binding>
+    </visual>
+</toast>`, toastScenario(n.Urgency), xmlEscape(n.Title), xmlEscape(n.Body), toastImage(n.Icon))
+}
+
+// toastScenario memetakan urgency ke scenario toast (reminder tetap tampil sampai ditutup)
+func toastScenario(u Urgency) string {
+	if u == UrgencyCritical {
+		return "reminder"
+	}
+	return "default"
+}
+
+// toastImage menampilkan cover anime sebagai logo toast
+func toastImage(icon string) string {
+	if icon == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(icon); err == nil {
+		icon = abs
+	}
+	uri := "file:///" + strings.TrimPrefix(filepath.ToSlash(icon), "/")
+	return fmt.Sprintf("\n            <image placement=\"appLogoOverride\" src=\"%s\"/>", xmlEscape(uri))
+}
+
+// xmlEscape supaya judul anime dengan & atau < tidak merusak XML toast
+func xmlEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(text)
+}
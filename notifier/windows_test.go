@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestToastCommandDoesNotRunTitle(t *testing.T) {
+	n := Notification{
+		Title:   "Re:Zero $(Remove-Item -Recurse C:\\) `\"@",
+		Body:    "'@\n$env:USERPROFILE & <friends> `n",
+		Urgency: UrgencyCritical,
+	}
+	cmd := toastCommand(n)
+
+	// Teks notifikasi tidak boleh masuk ke script PowerShell
+	for _, arg := range cmd.Args {
+		if strings.Contains(arg, "Remove-Item") || strings.Contains(arg, "USERPROFILE") {
+			t.Fatalf("notification text is part of the command: %q", arg)
+		}
+	}
+
+	var toastXML string
+	for _, env := range cmd.Env {
+		if value, ok := strings.CutPrefix(env, toastEnv+"="); ok {
+			toastXML = value
+		}
+	}
+	var toast struct {
+		Scenario string   `xml:"scenario,attr"`
+		Texts    []string `xml:"visual>binding>text"`
+	}
+	if err := xml.Unmarshal([]byte(toastXML), &toast); err != nil {
+		t.Fatalf("toast XML is invalid: %v\n%s", err, toastXML)
+	}
+	if toast.Scenario != "reminder" || len(toast.Texts) != 2 || toast.Texts[0] != n.Title || toast.Texts[1] != n.Body {
+		t.Errorf("toast = %+v, want the title and body unchanged", toast)
+	}
+}
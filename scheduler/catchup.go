@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"anime-reminder/controllers"
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/settings"
+	"anime-reminder/utils"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// stateFileName menyimpan waktu terakhir scheduler berjalan, di data directory
+	stateFileName = "scheduler_state.json"
+
+	// clockCheckInterval adalah interval pengecekan lompatan jam dinding (suspend, ganti jam).
+	// Sengaja pendek: timer reminder memakai jam monotonic yang berhenti saat suspend, jadi
+	// reminder yang jatuh selama laptop tidur baru terkirim paling lambat satu interval
+	// setelah bangun. Rebuild tengah malam tidak cukup karena bisa telat hampir sehari.
+	// Biayanya satu wakeup per menit saat idle, dan state tidak ditulis setiap menit
+	// (lihat lastRunSaveInterval).
+	clockCheckInterval = time.Minute
+
+	// clockJumpThreshold adalah selisih jam dinding vs monotonic yang dianggap lompatan
+	clockJumpThreshold = 30 * time.Second
+
+	// lastRunSaveInterval adalah jeda minimum menyimpan state saat scheduler hanya menunggu
+	lastRunSaveInterval = 5 * time.Minute
+
+	// maxCatchUp membatasi seberapa jauh ke belakang reminder yang terlewat dicari
+	maxCatchUp = 7 * 24 * time.Hour
+
+	// stateRetention adalah umur status tayangan (AiringState) yang dimuat dan disimpan,
+	// sehari lebih lama dari maxCatchUp supaya perbedaan zona siaran tetap tercakup
+	stateRetention = maxCatchUp + 24*time.Hour
+)
+
+// schedulerState adalah isi file stateFileName
+type schedulerState struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+// loadLastRun membaca waktu terakhir scheduler berjalan, zero jika belum pernah
+func loadLastRun() time.Time {
+	data, err := os.ReadFile(utils.DataPath(stateFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("⚠️ Failed to read scheduler state: %v", err)
+		}
+		return time.Time{}
+	}
+
+	var state schedulerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("⚠️ Failed to parse scheduler state: %v", err)
+		return time.Time{}
+	}
+	return state.LastRun
+}
+
+// saveLastRun menyimpan waktu terakhir scheduler berjalan
+func saveLastRun(at time.Time) {
+	data, err := json.Marshal(schedulerState{LastRun: at.Round(0)})
+	if err != nil {
+		return
+	}
+
+	path := utils.DataPath(stateFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		log.Printf("⚠️ Failed to save scheduler state: %v", err)
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		log.Printf("⚠️ Failed to save scheduler state: %v", err)
+	}
+}
+
+// clockJumped returns true if the wall clock moved differently from the monotonic clock
+// since last, e.g. after suspend/resume or a manual time change
+func clockJumped(last, now time.Time) bool {
+	wall := now.Round(0).Sub(last.Round(0))
+	monotonic := now.Sub(last)
+	diff := wall - monotonic
+	return diff > clockJumpThreshold || diff < -clockJumpThreshold
+}
+
+// catchUp mencari tayangan di rentang [from, now) yang belum di-fire.
+// Yang masih dalam grace window dikirim sebagai reminder terlambat,
+// sisanya dikumpulkan jadi satu ringkasan "you missed".
+func (s *scheduler) catchUp(from, now time.Time) {
+	if from.IsZero() || !now.After(from) {
+		return
+	}
+	if now.Sub(from) > maxCatchUp {
+		from = now.Add(-maxCatchUp)
+	}
+
+	allAnimes, err := s.ctrl.Anime.GetAllAnimes()
+	if err != nil {
+		log.Printf("Error fetching anime schedule: %v", err)
+		return
+	}
+
+	var missed []models.SlotAiring
+	for _, anime := range allAnimes {
+		if anime.Archived && !anime.HasEnded(from) {
+			// Di-archive manual, bukan karena musimnya selesai
+			continue
+		}
+		for _, airing := range anime.AiringsBetween(from, now) {
+			if !isActive(anime, airing.At) {
+				continue
+			}
+			if _, done := s.fired[newAiringKey(airing, 0)]; done {
+				continue
+			}
+			missed = append(missed, airing)
+		}
+	}
+	if len(missed) == 0 {
+		return
+	}
+
+	log.Printf("⏪ Catching up %d missed reminder(s) since %s", len(missed), from.Format("2006-01-02 15:04"))
+	s.handleMissed(missed, now)
+}
+
+// handleMissed mengirim tayangan yang terlambat: dalam grace window satu per satu,
+// lebih lama dari itu sebagai satu ringkasan
+func (s *scheduler) handleMissed(airings []models.SlotAiring, now time.Time) {
+	grace := settings.Get().MissedGrace()
+
+	var summary []models.SlotAiring
+	for _, airing := range airings {
+		s.markFired(airing, 0, now)
+		if now.Sub(airing.At) <= grace {
+			triggerReminder(s.ctrl, airing.Anime, airing.Slot, airing.At, now)
+			continue
+		}
+		summary = append(summary, airing)
+	}
+
+	if len(summary) > 0 {
+		sendMissedSummary(s.ctrl, summary, now)
+	}
+}
+
+// sendMissedSummary mengirim satu notifikasi berisi semua tayangan yang terlewat
+func sendMissedSummary(ctrl *controllers.Controllers, airings []models.SlotAiring, now time.Time) {
+	title := fmt.Sprintf("📭 You missed %d reminder(s)", len(airings))
+
+	lines := make([]string, 0, len(airings))
+	for _, airing := range airings {
+		local := airing.At.In(time.Local)
+		lines = append(lines, fmt.Sprintf("• %s — %s %s", airing.Anime.Title, models.WeekdayOf(local), local.Format("15:04")))
+	}
+	message := strings.Join(lines, "\n")
+
+	log.Printf("📭 Missed reminders: %d", len(airings))
+	event := models.ReminderEvent{
+		AnimeTitle: "Missed reminders",
+		Title:      title,
+		Message:    message,
+		FiredAt:    now,
+	}
+	if quietSuppressed(now) {
+		suppressedDelivery(&event)
+		logReminder(ctrl, &event)
+		return
+	}
+	n := notifier.Notification{Title: title, Body: message, Urgency: notifier.UrgencyLow}
+	event.Deliveries = deliver(n)
+	logAndBroadcast(ctrl, &event, n)
+}
+
+// formatAgo menghasilkan teks seperti "12 minutes ago" atau "1h 05m ago"
+func formatAgo(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes <= 1:
+		return "1 minute ago"
+	case minutes < 60:
+		return fmt.Sprintf("%d minutes ago", minutes)
+	default:
+		return fmt.Sprintf("%dh %02dm ago", minutes/60, minutes%60)
+	}
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock adalah sumber waktu scheduler. Produksi memakai SystemClock,
+// simulasi dan pengujian memakai ManualClock supaya waktu bisa diatur sendiri.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer adalah bagian dari time.Timer yang dipakai scheduler
+type Timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// SystemClock memakai jam sistem
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+func (SystemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{timer: time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t *systemTimer) C() <-chan time.Time   { return t.timer.C }
+func (t *systemTimer) Reset(d time.Duration) { t.timer.Reset(d) }
+func (t *systemTimer) Stop()                 { t.timer.Stop() }
+
+// ManualClock adalah jam yang hanya bergerak lewat Set/Advance.
+// Timer-nya fire saat jam dimajukan melewati deadline.
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+// NewManualClock membuat jam manual yang dimulai dari now
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &manualTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.deadline = c.now.Add(d)
+	t.active = true
+	c.timers = append(c.timers, t)
+	c.fireLocked()
+	return t
+}
+
+// Advance memajukan jam sebanyak d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set mengatur jam ke now (boleh mundur, untuk mensimulasikan lompatan jam)
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	c.fireLocked()
+}
+
+// fireLocked mengirim ke semua timer yang deadline-nya sudah lewat (lock harus dipegang)
+func (c *ManualClock) fireLocked() {
+	for _, t := range c.timers {
+		if t.active && !t.deadline.After(c.now) {
+			t.active = false
+			select {
+			case t.ch <- c.now:
+			default:
+			}
+		}
+	}
+}
+
+type manualTimer struct {
+	clock    *ManualClock
+	ch       chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *manualTimer) C() <-chan time.Time { return t.ch }
+
+func (t *manualTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	// Buang nilai lama seperti time.Timer.Reset sejak Go 1.23
+	select {
+	case <-t.ch:
+	default:
+	}
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	t.clock.fireLocked()
+}
+
+func (t *manualTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.active = false
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"anime-reminder/controllers"
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/settings"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// digestKind membedakan digest harian dan mingguan
+type digestKind int
+
+const (
+	// dailyDigest berisi semua tayangan hari ini, dikirim pagi hari
+	dailyDigest digestKind = iota
+	// weeklyDigest berisi tayangan Senin-Minggu depan, dikirim Minggu malam
+	weeklyDigest
+)
+
+func (k digestKind) String() string {
+	if k == weeklyDigest {
+		return "Weekly digest"
+	}
+	return "Daily digest"
+}
+
+// digestKey mencegah digest yang sama dikirim dua kali dalam satu hari
+func digestKey(kind digestKind, at time.Time) string {
+	return fmt.Sprintf("%d:%s", kind, at.In(time.Local).Format("2006-01-02"))
+}
+
+// clockOn returns the local time text ("HH:MM") on the day of day
+func clockOn(day time.Time, text string) (time.Time, bool) {
+	minutes, err := models.ParseClock(text)
+	if err != nil {
+		return time.Time{}, false
+	}
+	local := day.In(time.Local)
+	return time.Date(local.Year(), local.Month(), local.Day(), minutes/60, minutes%60, 0, 0, time.Local), true
+}
+
+// digestEvents mengembalikan digest yang jatuh di hari now sesuai settings
+func digestEvents(now time.Time) []fireEvent {
+	s := settings.Get()
+
+	var events []fireEvent
+	if s.DailyDigestTime != "" {
+		if at, ok := clockOn(now, s.DailyDigestTime); ok {
+			events = append(events, fireEvent{At: at, Kind: eventDigest, Digest: dailyDigest})
+		}
+	}
+	if s.WeeklyDigest && now.In(time.Local).Weekday() == time.Sunday {
+		if at, ok := clockOn(now, s.WeeklyDigestClock()); ok {
+			events = append(events, fireEvent{At: at, Kind: eventDigest, Digest: weeklyDigest})
+		}
+	}
+	return events
+}
+
+// buildDigest menyusun isi digest. ok false jika tidak ada tayangan sama sekali.
+func buildDigest(ctrl *controllers.Controllers, kind digestKind, now time.Time) (title, message string, ok bool) {
+	from := nextMidnight(now).AddDate(0, 0, -1)
+	to := nextMidnight(now)
+	if kind == weeklyDigest {
+		from = nextMidnight(now)
+		to = from.AddDate(0, 0, 7)
+	}
+
+	allAnimes, err := ctrl.Anime.GetAllAnimes()
+	if err != nil {
+		log.Printf("Error fetching anime schedule: %v", err)
+		return "", "", false
+	}
+
+	var airings []models.SlotAiring
+	for _, anime := range allAnimes {
+		if anime.Archived {
+			continue
+		}
+		for _, airing := range anime.AiringsBetween(from, to) {
+			if isActive(anime, airing.At) {
+				airings = append(airings, airing)
+			}
+		}
+	}
+	if len(airings) == 0 {
+		return "", "", false
+	}
+	sort.SliceStable(airings, func(i, j int) bool { return airings[i].At.Before(airings[j].At) })
+
+	tmpl := settings.Get().DigestTemplate
+	var lines []string
+	lastDay := ""
+	for _, airing := range airings {
+		local := airing.At.In(time.Local)
+		line := models.DigestLine{
+			Title: airing.Anime.Title,
+			Slot:  airing.Slot.Label,
+			Day:   models.WeekdayOf(local).String(),
+			Time:  local.Format("15:04"),
+		}
+		if episode, total, err := ctrl.Episode.CurrentEpisode(airing.Anime.Id, airing.At); err == nil && episode != nil {
+			line.Episode = formatEpisode(episode, total)
+		}
+
+		text, err := models.RenderDigestLine(tmpl, line)
+		if err != nil {
+			log.Printf("⚠️ Invalid digest template: %v", err)
+			text, _ = models.RenderDigestLine("", line)
+		}
+
+		// Digest mingguan dikelompokkan per hari
+		if kind == weeklyDigest && line.Day != lastDay {
+			lines = append(lines, line.Day)
+			lastDay = line.Day
+		}
+		lines = append(lines, "• "+text)
+	}
+
+	if kind == weeklyDigest {
+		title = fmt.Sprintf("🗓️ Next week: %d airing(s)", len(airings))
+	} else {
+		title = fmt.Sprintf("📅 Today: %d airing(s)", len(airings))
+	}
+	return title, strings.Join(lines, "\n"), true
+}
+
+// sendDigest mengirim digest lewat channel reminder biasa dan mencatatnya di history
+func sendDigest(ctrl *controllers.Controllers, kind digestKind, title, message string, now time.Time) {
+	log.Printf("📅 %s: %s", kind, title)
+	event := models.ReminderEvent{
+		AnimeTitle: kind.String(),
+		Title:      title,
+		Message:    message,
+		FiredAt:    now,
+	}
+	if quietSuppressed(now) {
+		suppressedDelivery(&event)
+		logReminder(ctrl, &event)
+		return
+	}
+
+	n := notifier.Notification{Title: title, Body: message, Urgency: notifier.UrgencyLow}
+	event.Deliveries = deliver(n)
+	logReminder(ctrl, &event)
+	broadcastInBackground(ctrl, event.Id, n, notifier.BroadcastDigest)
+}
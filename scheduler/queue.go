@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"anime-reminder/models"
+	"container/heap"
+	"time"
+)
+
+// eventKind membedakan isi antrian scheduler
+type eventKind int
+
+const (
+	// eventAiring adalah tayangan sebuah slot yang perlu di-reminder
+	eventAiring eventKind = iota
+	// eventAdvance adalah advance reminder beberapa menit sebelum tayang
+	eventAdvance
+	// eventSnooze adalah reminder yang di-snooze dan perlu dikirim ulang
+	eventSnooze
+	// eventDigest adalah digest harian / mingguan
+	eventDigest
+	// eventRebuild menyusun ulang antrian, misalnya saat ganti hari
+	eventRebuild
+)
+
+// fireEvent adalah satu waktu fire di antrian scheduler
+type fireEvent struct {
+	At     time.Time
+	Kind   eventKind
+	Airing models.SlotAiring
+	// Offset hanya diisi untuk eventAdvance
+	Offset models.ReminderOffset
+	// Snooze hanya diisi untuk eventSnooze
+	Snooze models.Snooze
+	// Digest hanya diisi untuk eventDigest
+	Digest digestKind
+}
+
+// key mengidentifikasi satu reminder (anime + slot + tanggal tayang + offset) untuk mencegah fire dua kali
+func (e fireEvent) key() airingKey {
+	return newAiringKey(e.Airing, e.Offset.Minutes)
+}
+
+// airingKey sama dengan kunci unik models.AiringState, supaya status di database
+// bisa dimuat langsung ke map fired
+type airingKey struct {
+	AnimeId uint
+	SlotId  uint
+	AirDate string
+	// Offset adalah menit sebelum tayang, 0 untuk reminder saat tayang
+	Offset int
+}
+
+func newAiringKey(airing models.SlotAiring, offsetMinutes int) airingKey {
+	return airingKey{
+		AnimeId: airing.Anime.Id,
+		SlotId:  airing.Slot.Id,
+		AirDate: airing.Anime.AiringDate(airing.At),
+		Offset:  offsetMinutes,
+	}
+}
+
+func stateKey(state models.AiringState) airingKey {
+	return airingKey{AnimeId: state.AnimeId, SlotId: state.SlotId, AirDate: state.AirDate, Offset: state.OffsetMinutes}
+}
+
+// eventQueue adalah min-heap berdasarkan waktu fire, dipakai lewat container/heap
+type eventQueue []fireEvent
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].At.Equal(q[j].At) {
+		// Reminder lebih dulu daripada rebuild di detik yang sama
+		return q[i].Kind < q[j].Kind
+	}
+	return q[i].At.Before(q[j].At)
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x any) { *q = append(*q, x.(fireEvent)) }
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	event := old[n-1]
+	*q = old[:n-1]
+	return event
+}
+
+// push menambahkan event ke antrian
+func (q *eventQueue) push(event fireEvent) {
+	heap.Push(q, event)
+}
+
+// peek returns the earliest event without removing it
+func (q eventQueue) peek() (fireEvent, bool) {
+	if len(q) == 0 {
+		return fireEvent{}, false
+	}
+	return q[0], true
+}
+
+// pop mengambil event paling awal dari antrian
+func (q *eventQueue) pop() fireEvent {
+	return heap.Pop(q).(fireEvent)
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"anime-reminder/models"
+	"anime-reminder/settings"
+	"log"
+	"time"
+)
+
+// quietSuppressed returns true if quiet hours at now suppress every reminder.
+// Ringtone (mute / reduced volume) diatur terpisah oleh utils lewat SetVolumeFunc.
+func quietSuppressed(now time.Time) bool {
+	w, ok := settings.Get().QuietWindowAt(now)
+	return ok && w.Mode == models.QuietSilent
+}
+
+// suppressedDelivery dicatat di history untuk reminder yang tidak dikirim karena quiet hours,
+// supaya masih bisa di-resend dari tab History
+func suppressedDelivery(event *models.ReminderEvent) {
+	log.Printf("🌙 Quiet hours, reminder suppressed: %s", event.AnimeTitle)
+	event.Deliveries = []models.ReminderDelivery{{
+		Channel: "desktop",
+		Success: false,
+		Error:   "suppressed by quiet hours",
+	}}
+}
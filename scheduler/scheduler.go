@@ -2,132 +2,621 @@ package scheduler
 
 import (
 	"anime-reminder/controllers"
-	"anime-reminder/database"
 	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/settings"
 	"anime-reminder/utils"
 	"fmt"
 	"log"
+	"math"
+	"strings"
 	"time"
 )
 
-// Scheduler runs the anime reminder checker
-func Scheduler(stopCh <-chan bool) {
-	// Check setiap 30 detik lebih efisien
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
+// lateTolerance adalah keterlambatan maksimum sebuah reminder masih dianggap "airing now"
+const lateTolerance = time.Minute
 
-	// Map untuk tracking anime yang sudah di-trigger hari ini
-	triggeredToday := make(map[uint]time.Time)
+// scheduler menyimpan antrian waktu fire berikutnya. Hanya dipakai dari goroutine Run.
+type scheduler struct {
+	ctrl  *controllers.Controllers
+	clock Clock
+	queue eventQueue
+	// fired mencatat tayangan yang sudah di-fire supaya rebuild tidak menjadwalkannya lagi.
+	// Isinya juga disimpan di database (AiringState) dan dimuat lagi saat start.
+	fired map[airingKey]time.Time
+	// digests mencatat digest yang sudah dikirim (lihat digestKey)
+	digests map[string]bool
+	// record diisi saat simulasi: event dicatat, bukan dikirim, dan data tidak diubah
+	record func(event fireEvent, now time.Time)
+	// simulatedSnoozes mencatat snooze yang sudah dicatat simulasi. Snooze tidak dihapus
+	// saat simulasi, jadi tanpa ini setiap rebuild menjadwalkannya lagi.
+	simulatedSnoozes map[uint]bool
+}
+
+func newScheduler(ctrl *controllers.Controllers, clock Clock) *scheduler {
+	return &scheduler{
+		ctrl:    ctrl,
+		clock:   clock,
+		fired:   make(map[airingKey]time.Time),
+		digests: make(map[string]bool),
+
+		simulatedSnoozes: make(map[uint]bool),
+	}
+}
+
+// Scheduler runs the anime reminder loop with the system clock
+func Scheduler(stopCh <-chan bool, ctrl *controllers.Controllers) {
+	Run(stopCh, ctrl, SystemClock{})
+}
+
+// Run menjalankan loop scheduler. Ia tidur dengan satu timer sampai event
+// berikutnya di antrian, dan menyusun ulang antrian setiap kali data anime berubah.
+// Reminder yang terlewat (aplikasi mati, suspend, jam diubah) dikirim menyusul.
+func Run(stopCh <-chan bool, ctrl *controllers.Controllers, clock Clock) {
+	s := newScheduler(ctrl, clock)
+
+	now := clock.Now()
+	s.loadFired(now)
+	s.catchUp(loadLastRun(), now)
+	s.rebuild(now)
+	saveLastRun(now)
+
+	timer := clock.NewTimer(s.untilNext(clock.Now()))
+	defer timer.Stop()
+
+	// Timer Go memakai jam monotonic yang berhenti saat suspend, jadi jam dinding
+	// dicek berkala untuk mendeteksi lompatan. Wakeup tiap clockCheckInterval saat
+	// idle adalah harga yang disengaja, lihat komentar konstantanya.
+	clockCheck := clock.NewTimer(clockCheckInterval)
+	defer clockCheck.Stop()
+	lastCheck := now
+	lastSaved := now
 
 	for {
+		// handled true jika iterasi ini mengirim reminder atau menyusun ulang antrian
+		handled := true
 		select {
-		case <-ticker.C:
-			checkAnimeSchedule(triggeredToday)
+		case <-timer.C():
+			s.fireDue(clock.Now())
+		case <-ctrl.Changes():
+			s.rebuild(clock.Now())
+		case <-clockCheck.C():
+			now := clock.Now()
+			handled = clockJumped(lastCheck, now)
+			if handled {
+				log.Printf("⏰ Clock jumped (last check %s), re-checking schedule", lastCheck.Format("2006-01-02 15:04:05"))
+				s.catchUp(lastCheck, now)
+				s.rebuild(now)
+			}
+			lastCheck = now
+			clockCheck.Reset(clockCheckInterval)
 		case <-stopCh:
+			saveLastRun(clock.Now())
 			log.Println("Scheduler stopped")
 			return
 		}
+
+		// Tanpa kejadian apa pun cukup simpan sesekali, jangan menulis file setiap menit
+		if now := clock.Now(); handled || now.Sub(lastSaved) >= lastRunSaveInterval {
+			saveLastRun(now)
+			lastSaved = now
+		}
+		timer.Reset(s.untilNext(clock.Now()))
 	}
 }
 
-func checkAnimeSchedule(triggeredToday map[uint]time.Time) {
-	now := time.Now()
-	today := now.Weekday().String()
-
-	dayMap := map[string]string{
-		"Monday":    "Senin",
-		"Tuesday":   "Selasa",
-		"Wednesday": "Rabu",
-		"Thursday":  "Kamis",
-		"Friday":    "Jumat",
-		"Saturday":  "Sabtu",
-		"Sunday":    "Minggu",
+// untilNext returns how long to sleep until the earliest queued event
+func (s *scheduler) untilNext(now time.Time) time.Duration {
+	event, ok := s.queue.peek()
+	if !ok {
+		// Tidak pernah terjadi karena rebuild selalu menambahkan event rebuild
+		return time.Hour
 	}
+	if wait := event.At.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
 
-	todayID := dayMap[today]
-
-	db := database.GetDB()
-	var animes []models.Anime
+// rebuild mengisi ulang antrian dengan semua tayangan sampai tengah malam berikutnya,
+// ditambah event rebuild di tengah malam untuk hari berikutnya
+func (s *scheduler) rebuild(now time.Time) {
+	s.queue = s.queue[:0]
 
-	// Ambil anime yang jadwalnya hari ini
-	result := db.Where("day = ?", todayID).Find(&animes)
-	if result.Error != nil {
-		log.Printf("Error fetching anime schedule: %v", result.Error)
+	allAnimes, err := s.ctrl.Anime.GetAllAnimes()
+	if err != nil {
+		log.Printf("Error fetching anime schedule: %v", err)
+		s.queue.push(fireEvent{At: now.Add(time.Minute), Kind: eventRebuild})
 		return
 	}
 
-	// Cleanup triggered map jika sudah ganti hari
-	currentDate := now.Format("2006-01-02")
-	for animeID, lastTriggered := range triggeredToday {
-		if lastTriggered.Format("2006-01-02") != currentDate {
-			delete(triggeredToday, animeID)
+	// Archive otomatis anime yang finale-nya sudah tayang (tidak saat simulasi)
+	for _, anime := range allAnimes {
+		if s.record == nil && !anime.Archived && anime.HasEnded(now) {
+			if err := s.ctrl.Anime.SetArchived(anime.Id, true); err != nil {
+				log.Printf("⚠️ Failed to archive %s: %v", anime.Title, err)
+			} else {
+				log.Printf("📦 Season ended, archived: %s", anime.Title)
+			}
 		}
 	}
 
-	for _, anime := range animes {
-		// Cek apakah anime ini sudah di-trigger hari ini
-		if lastTriggered, exists := triggeredToday[anime.Id]; exists {
-			// Jika sudah di-trigger dalam 1 jam terakhir, skip
-			if time.Since(lastTriggered) < 1*time.Hour {
+	// Lupakan tayangan lama, cukup simpan yang masih mungkin masuk antrian lagi
+	for key, firedAt := range s.fired {
+		if now.Sub(firedAt) > 24*time.Hour {
+			delete(s.fired, key)
+		}
+	}
+	// Status di database hanya dibutuhkan selama masih bisa di-catch up
+	if s.record == nil {
+		if err := s.ctrl.Airing.Prune(now.Add(-stateRetention)); err != nil {
+			log.Printf("⚠️ Failed to prune reminder state: %v", err)
+		}
+	}
+
+	midnight := nextMidnight(now)
+	// Mulai sedikit ke belakang supaya tayangan yang sedang berlangsung tidak terlewat
+	from := now.Add(-lateTolerance)
+	defaultOffsets := settings.Get().DefaultOffsets
+	count := 0
+	for _, anime := range allAnimes {
+		if anime.Archived {
+			continue
+		}
+		offsets := anime.LeadOffsets(defaultOffsets)
+		lead := time.Duration(maxOffsetMinutes(offsets)) * time.Minute
+
+		// Tayangan setelah tengah malam bisa punya advance reminder sebelum tengah malam
+		for _, airing := range anime.AiringsBetween(from, midnight.Add(lead)) {
+			// Musim dicek per tayangan: hari lokal bisa berbeda dengan tanggal siaran
+			if !isActive(anime, airing.At) {
 				continue
 			}
+			events := []fireEvent{{At: airing.At, Kind: eventAiring, Airing: airing}}
+			for _, offset := range offsets {
+				fireAt := airing.At.Add(-time.Duration(offset.Minutes) * time.Minute)
+				events = append(events, fireEvent{At: fireAt, Kind: eventAdvance, Airing: airing, Offset: offset})
+			}
+
+			for _, event := range events {
+				if event.At.Before(from) || !event.At.Before(midnight) {
+					continue
+				}
+				if _, done := s.fired[event.key()]; done {
+					continue
+				}
+				s.queue.push(event)
+				count++
+			}
 		}
+	}
+	// Snooze dikirim walaupun waktunya sudah lewat (misalnya aplikasi sempat ditutup)
+	snoozes, err := s.ctrl.Snooze.Pending()
+	if err != nil {
+		log.Printf("⚠️ Failed to load snoozed reminders: %v", err)
+	}
+	for _, snooze := range snoozes {
+		if s.simulatedSnoozes[snooze.Id] {
+			continue
+		}
+		s.queue.push(fireEvent{At: snooze.Until, Kind: eventSnooze, Snooze: snooze})
+		count++
+	}
+	for _, event := range digestEvents(now) {
+		if event.At.Before(from) || s.digests[digestKey(event.Digest, event.At)] {
+			continue
+		}
+		s.queue.push(event)
+	}
 
-		// Bandingkan waktu: ambil jam dan menit dari anime.Time
-		animeHour := anime.Time.Hour()
-		animeMinute := anime.Time.Minute()
+	s.queue.push(fireEvent{At: midnight, Kind: eventRebuild})
+
+	if s.record == nil {
+		log.Printf("🗓️ Scheduler queue rebuilt: %d reminder(s) until %s", count, midnight.Format("2006-01-02 15:04"))
+	}
+}
 
-		currentHour := now.Hour()
-		currentMinute := now.Minute()
+// fireDue menjalankan semua event yang waktunya sudah lewat
+func (s *scheduler) fireDue(now time.Time) {
+	for {
+		event, ok := s.queue.peek()
+		if !ok || event.At.After(now) {
+			return
+		}
+		s.queue.pop()
 
-		// Trigger jika waktu cocok (dalam rentang 1 menit)
-		if animeHour == currentHour && animeMinute == currentMinute {
-			triggerReminder(anime)
-			triggeredToday[anime.Id] = now
+		switch event.Kind {
+		case eventRebuild:
+			// Antrian diganti seluruhnya, event yang sudah due akan di-fire di putaran berikutnya
+			s.rebuild(now)
+			return
+		case eventAiring:
+			if _, done := s.fired[event.key()]; done {
+				continue
+			}
+			if now.Sub(event.At) > lateTolerance {
+				// Timer terlambat (misalnya setelah suspend)
+				s.handleMissed([]models.SlotAiring{event.Airing}, now)
+				continue
+			}
+			s.markFired(event.Airing, 0, now)
+			if s.record != nil {
+				s.record(event, now)
+				continue
+			}
+			triggerReminder(s.ctrl, event.Airing.Anime, event.Airing.Slot, event.At, now)
+		case eventAdvance:
+			if _, done := s.fired[event.key()]; done {
+				continue
+			}
+			s.markFired(event.Airing, event.Offset.Minutes, now)
+			// Advance reminder yang terlambat tidak ada gunanya setelah tayang dimulai
+			if !now.Before(event.Airing.At) {
+				log.Printf("⏭️ Skipped late advance reminder: %s (%d min)", event.Airing.Anime.Title, event.Offset.Minutes)
+				continue
+			}
+			if s.record != nil {
+				s.record(event, now)
+				continue
+			}
+			triggerAdvanceReminder(s.ctrl, event.Airing, event.Offset, now)
+		case eventSnooze:
+			if s.record != nil {
+				s.simulatedSnoozes[event.Snooze.Id] = true
+				s.record(event, now)
+				continue
+			}
+			fireSnooze(s.ctrl, event.Snooze, now)
+		case eventDigest:
+			key := digestKey(event.Digest, event.At)
+			if s.digests[key] {
+				continue
+			}
+			s.digests[key] = true
+			title, message, ok := buildDigest(s.ctrl, event.Digest, now)
+			if !ok {
+				log.Printf("📭 Nothing airing, %s skipped", strings.ToLower(event.Digest.String()))
+				continue
+			}
+			if s.record != nil {
+				s.record(event, now)
+				continue
+			}
+			sendDigest(s.ctrl, event.Digest, title, message, now)
 		}
 	}
 }
 
-func triggerReminder(anime models.Anime) {
-	log.Printf("🎬 Reminder: %s is airing now!", anime.Title)
+// loadFired memuat tayangan yang sudah di-fire atau di-acknowledge dari database,
+// supaya reminder tidak dikirim dua kali setelah restart
+func (s *scheduler) loadFired(now time.Time) {
+	states, err := s.ctrl.Airing.Since(now.Add(-stateRetention))
+	if err != nil {
+		log.Printf("⚠️ Failed to load reminder state: %v", err)
+		return
+	}
+	for _, state := range states {
+		switch {
+		case state.FiredAt != nil:
+			s.fired[stateKey(state)] = *state.FiredAt
+		case state.AcknowledgedAt != nil:
+			s.fired[stateKey(state)] = *state.AcknowledgedAt
+		}
+	}
+}
 
-	// 1. Kirim notifikasi desktop
-	title := "🎬 Anime Reminder"
-	message := fmt.Sprintf("%s is airing now!\n%s at %s",
-		anime.Title,
-		anime.Day,
-		anime.Time.Format("15:04"))
+// markFired mencatat reminder sebagai sudah di-fire, di memori dan di database
+// (tidak saat simulasi)
+func (s *scheduler) markFired(airing models.SlotAiring, offsetMinutes int, now time.Time) {
+	key := newAiringKey(airing, offsetMinutes)
+	s.fired[key] = now
+	if s.record != nil {
+		return
+	}
+	if err := s.ctrl.Airing.MarkFired(key.AnimeId, key.SlotId, key.AirDate, key.Offset, now); err != nil {
+		log.Printf("⚠️ Failed to save reminder state: %v", err)
+	}
+}
 
-	err := utils.SendNotification(title, message)
+// maxOffsetMinutes returns the largest lead time in offsets
+func maxOffsetMinutes(offsets []models.ReminderOffset) int {
+	longest := 0
+	for _, offset := range offsets {
+		if offset.Minutes > longest {
+			longest = offset.Minutes
+		}
+	}
+	return longest
+}
+
+// isActive returns true if the season is running on the broadcast date of at
+// (sudah premiere, dan belum lewat finale kecuali masih di hari finale)
+func isActive(anime models.Anime, at time.Time) bool {
+	if !anime.HasPremiered(at) {
+		return false
+	}
+	return !anime.HasEnded(at) || anime.IsFinaleDay(at)
+}
+
+// nextMidnight returns the start of the next local day
+func nextMidnight(now time.Time) time.Time {
+	local := now.In(time.Local)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.Local)
+}
+
+func triggerReminder(ctrl *controllers.Controllers, anime models.Anime, slot models.ScheduleSlot, airAt, now time.Time) {
+	// Reminder yang dikirim menyusul menyebutkan sudah berapa lama tayangnya mulai
+	status := "is airing now!"
+	if late := now.Sub(airAt); late > lateTolerance {
+		status = "started " + formatAgo(late)
+	}
+	log.Printf("🎬 Reminder: %s %s", anime.Title, status)
+
+	// Cari episode yang sedang tayang (jika jadwal episode sudah di-generate)
+	episodeInfo := ""
+	episode, total, err := ctrl.Episode.CurrentEpisode(anime.Id, airAt)
 	if err != nil {
-		log.Printf("⚠️ Failed to send notification: %v", err)
-	} else {
+		log.Printf("⚠️ Failed to get episode: %v", err)
+	} else if episode != nil {
+		episodeInfo = formatEpisode(episode, total)
+		if err := ctrl.Episode.MarkAired(episode); err != nil {
+			log.Printf("⚠️ Failed to mark episode as aired: %v", err)
+		}
+	}
+
+	// 1. Kirim notifikasi desktop
+	title := reminderTitle(anime, now)
+	name := anime.Title
+	if episodeInfo != "" {
+		name = fmt.Sprintf("%s (%s)", anime.Title, episodeInfo)
+	}
+	message := fmt.Sprintf("%s %s\n%s",
+		name,
+		status,
+		anime.SlotSummary(slot, airAt))
+
+	event := models.ReminderEvent{
+		AnimeId:    anime.Id,
+		AnimeTitle: anime.Title,
+		SlotId:     slot.Id,
+		AirDate:    anime.AiringDate(airAt),
+		Title:      title,
+		Message:    message,
+		FiredAt:    now,
+	}
+	if episode != nil {
+		event.EpisodeNumber = episode.Number
+	}
+
+	// Quiet hours "suppress everything": hanya dicatat di history
+	if quietSuppressed(now) {
+		suppressedDelivery(&event)
+		logReminder(ctrl, &event)
+		return
+	}
+
+	// Notifikasi punya tombol snooze/dismiss jika backend mendukung
+	n := withActions(ctrl, &event, reminderNotification(anime, event, episodeInfo, airAt))
+	event.Deliveries = deliver(n)
+	if event.Delivered() {
 		log.Printf("✅ Notification sent for: %s", anime.Title)
 	}
 
-	// 2. Play ringtone jika ada
-	if anime.RingToneId > 0 {
-		ringToneController := &controllers.RingToneController{}
-		ringTone, err := ringToneController.GetRingToneById(anime.RingToneId)
+	// 2. Play ringtone jika ada (ringtone slot, atau default anime) selama 30 detik,
+	// volume mengikuti quiet hours
+	playRingTone(ctrl, anime.SlotRingToneId(slot), 30*time.Second, &event)
 
-		if err != nil {
-			log.Printf("⚠️ Failed to get ringtone: %v", err)
-		} else if ringTone.SongPath != "" {
-			// Play audio selama 15 detik
-			duration := 30 * time.Second
-			utils.PlayAudioAsync(ringTone.SongPath, duration)
-			log.Printf("🔊 Playing ringtone: %s", ringTone.Name)
+	// 3. Simpan ke history dan kirim ke channel remote, lalu tampilkan di tray & popup
+	// untuk snooze/dismiss
+	logAndBroadcast(ctrl, &event, n)
+	setActive(event)
+}
+
+// triggerAdvanceReminder mengirim reminder beberapa menit sebelum tayang,
+// dengan template dan ringtone milik offset
+func triggerAdvanceReminder(ctrl *controllers.Controllers, airing models.SlotAiring, offset models.ReminderOffset, now time.Time) {
+	anime := airing.Anime
+	minutes := int(math.Ceil(airing.At.Sub(now).Minutes()))
+
+	data := models.OffsetMessage{
+		Title:   anime.Title,
+		Slot:    airing.Slot.Label,
+		Minutes: minutes,
+		Time:    airing.At.In(time.Local).Format("15:04"),
+	}
+	event := models.ReminderEvent{
+		AnimeId:       anime.Id,
+		AnimeTitle:    anime.Title,
+		SlotId:        airing.Slot.Id,
+		AirDate:       anime.AiringDate(airing.At),
+		OffsetMinutes: offset.Minutes,
+		Title:         "⏰ Starting Soon",
+		FiredAt:       now,
+	}
+
+	episode, total, err := ctrl.Episode.CurrentEpisode(anime.Id, airing.At)
+	if err != nil {
+		log.Printf("⚠️ Failed to get episode: %v", err)
+	} else if episode != nil {
+		data.Episode = formatEpisode(episode, total)
+		event.EpisodeNumber = episode.Number
+	}
+
+	message, err := offset.Render(data)
+	if err != nil {
+		log.Printf("⚠️ Invalid template for %d-minute reminder: %v", offset.Minutes, err)
+		message, _ = models.ReminderOffset{}.Render(data)
+	}
+	event.Message = message
+	log.Printf("⏰ Advance reminder: %s", message)
+
+	if quietSuppressed(now) {
+		suppressedDelivery(&event)
+		logReminder(ctrl, &event)
+		return
+	}
+	n := reminderNotification(anime, event, data.Episode, airing.At)
+	event.Deliveries = deliver(n)
+
+	// Ringtone advance reminder opsional dan lebih pendek
+	playRingTone(ctrl, offset.RingToneId, 10*time.Second, &event)
+
+	logAndBroadcast(ctrl, &event, n)
+}
+
+// deliver mengirim notifikasi lewat fallback chain notifier dan mencatat
+// hasilnya per backend yang dicoba
+func deliver(n notifier.Notification) []models.ReminderDelivery {
+	attempts := notifier.Send(n)
+	if !notifier.Delivered(attempts) {
+		log.Printf("⚠️ Failed to send notification: %v", attempts[len(attempts)-1].Err)
+	}
+	return toDeliveries(attempts)
+}
+
+// broadcastInBackground mengirim n ke channel remote (webhook, Telegram, ...) lewat
+// broadcast, di samping notifikasi desktop. Server yang mati dengan retry bisa butuh
+// beberapa menit, jadi dikirim di goroutine sendiri dan hasilnya ditambahkan ke
+// history event eventID setelah selesai.
+func broadcastInBackground(ctrl *controllers.Controllers, eventID uint, n notifier.Notification, broadcast func(notifier.Notification) []notifier.Delivery) {
+	go func() {
+		deliveries := toDeliveries(broadcast(n))
+		if len(deliveries) == 0 || eventID == 0 {
+			return
+		}
+		if err := ctrl.History.AddDeliveries(eventID, deliveries); err != nil {
+			log.Printf("⚠️ Failed to save remote deliveries: %v", err)
+		}
+	}()
+}
+
+// toDeliveries mengubah hasil notifier menjadi ReminderDelivery untuk history
+func toDeliveries(attempts []notifier.Delivery) []models.ReminderDelivery {
+	deliveries := make([]models.ReminderDelivery, 0, len(attempts))
+	for _, attempt := range attempts {
+		delivery := models.ReminderDelivery{Channel: attempt.Backend, Success: attempt.Err == nil}
+		if attempt.Err != nil {
+			delivery.Error = attempt.Err.Error()
 		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+// logReminder menyimpan event ke tabel reminder_events
+func logReminder(ctrl *controllers.Controllers, event *models.ReminderEvent) {
+	log.Printf("📝 Reminder logged: [%s] %s",
+		event.FiredAt.Format("2006-01-02 15:04:05"),
+		event.AnimeTitle)
+
+	if err := ctrl.History.Record(event); err != nil {
+		log.Printf("⚠️ Failed to save reminder history: %v", err)
+	}
+}
+
+// logAndBroadcast menyimpan event yang sudah dikirim ke desktop, lalu mengirim n
+// ke channel remote. Dipakai semua jenis reminder supaya channel remote menerima
+// hal yang sama dengan desktop.
+func logAndBroadcast(ctrl *controllers.Controllers, event *models.ReminderEvent, n notifier.Notification) {
+	logReminder(ctrl, event)
+	broadcastInBackground(ctrl, event.Id, n, notifier.Broadcast)
+}
+
+// ResendReminder mengirim ulang notifikasi dari event di history (tanpa ringtone)
+// dan mencatatnya sebagai event baru
+func ResendReminder(ctrl *controllers.Controllers, eventID uint) (*models.ReminderEvent, error) {
+	original, err := ctrl.History.GetEventById(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := models.ReminderEvent{
+		AnimeId:       original.AnimeId,
+		AnimeTitle:    original.AnimeTitle,
+		SlotId:        original.SlotId,
+		AirDate:       original.AirDate,
+		OffsetMinutes: original.OffsetMinutes,
+		EpisodeNumber: original.EpisodeNumber,
+		Title:         original.Title,
+		Message:       original.Message,
+		FiredAt:       time.Now(),
+		ResentFromId:  original.Id,
 	}
+	anime, err := ctrl.Anime.GetAnimeById(original.AnimeId)
+	if err != nil {
+		anime = &models.Anime{Id: original.AnimeId, Title: original.AnimeTitle}
+	}
+	n := reminderNotification(*anime, event, episodeLabel(event), time.Time{})
+	event.Deliveries = deliver(n)
+	logAndBroadcast(ctrl, &event, n)
+
+	// Hasil channel remote menyusul di history
+	if !event.Delivered() {
+		return &event, fmt.Errorf("failed to re-send reminder: %s", event.Deliveries[len(event.Deliveries)-1].Error)
+	}
+	return &event, nil
+}
 
-	// 3. Log reminder ke file (opsional)
-	logReminder(anime)
+// reminderTitle memberi judul khusus untuk hari premiere dan finale
+func reminderTitle(anime models.Anime, now time.Time) string {
+	switch {
+	case anime.IsPremiereDay(now):
+		return "🎉 Premiere Today"
+	case anime.IsFinaleDay(now):
+		return "🏁 Finale Today"
+	default:
+		return "🎬 Anime Reminder"
+	}
+}
+
+// reminderNotification menyusun notifikasi reminder lengkap dengan cover anime
+// dan data mentah (judul, episode, jam tayang) untuk backend berbasis template
+func reminderNotification(anime models.Anime, event models.ReminderEvent, episode string, airAt time.Time) notifier.Notification {
+	tag := "tv"
+	if event.OffsetMinutes > 0 {
+		tag = "alarm_clock"
+	}
+	return notifier.Notification{
+		Title:      event.Title,
+		Body:       event.Message,
+		Icon:       coverIcon(anime),
+		Urgency:    notifier.UrgencyNormal,
+		AnimeId:    anime.Id,
+		AnimeTitle: anime.Title,
+		Episode:    episode,
+		AirAt:      airAt,
+		URL:        anime.StreamURL,
+		Tags:       []string{tag},
+		Tag:        airingTag(event),
+	}
 }
 
-func logReminder(anime models.Anime) {
-	// Bisa disimpan ke file log atau database untuk history
-	log.Printf("📝 Reminder logged: [%s] %s at %s",
-		time.Now().Format("2006-01-02 15:04:05"),
-		anime.Title,
-		anime.Time.Format("15:04"))
+// coverIcon returns the cached, resized cover of the anime, atau "" jika tidak ada
+func coverIcon(anime models.Anime) string {
+	if anime.ImagePath == "" {
+		return ""
+	}
+	icon, err := utils.CoverIcon(anime.ImagePath, utils.CoverIconSize)
+	if err != nil {
+		log.Printf("⚠️ Failed to prepare cover for %s: %v", anime.Title, err)
+		return ""
+	}
+	return icon
+}
+
+// episodeLabel dipakai saat episode hanya diketahui dari nomornya (snooze, resend)
+func episodeLabel(event models.ReminderEvent) string {
+	if event.EpisodeNumber <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Episode %d", event.EpisodeNumber)
+}
+
+// formatEpisode menghasilkan teks seperti "Episode 7/12" atau "Episode 7/12: Judul"
+func formatEpisode(episode *models.Episode, total int) string {
+	text := fmt.Sprintf("Episode %d/%d", episode.Number, total)
+	if episode.Title != "" {
+		text += ": " + episode.Title
+	}
+	return text
 }
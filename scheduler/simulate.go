@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"anime-reminder/controllers"
+	"errors"
+	"time"
+)
+
+// MaxSimulationRange membatasi panjang rentang simulasi
+const MaxSimulationRange = 366 * 24 * time.Hour
+
+// SimulatedReminder adalah satu reminder yang akan di-fire menurut simulasi
+type SimulatedReminder struct {
+	FireAt time.Time
+	// Kind adalah "airing", "advance", "snooze" atau "digest"
+	Kind          string
+	AnimeId       uint
+	AnimeTitle    string
+	SlotLabel     string
+	AirAt         time.Time
+	OffsetMinutes int
+}
+
+// Simulate menjalankan scheduler dengan ManualClock dari from sampai to tanpa
+// mengirim notifikasi atau mengubah data, lalu mengembalikan semua reminder
+// yang akan di-fire, urut waktu. Pergantian hari, DST dan batas musim
+// diproses persis seperti scheduler sungguhan.
+func Simulate(ctrl *controllers.Controllers, from, to time.Time) ([]SimulatedReminder, error) {
+	if !to.After(from) {
+		return nil, errors.New("simulation end must be after start")
+	}
+	if to.Sub(from) > MaxSimulationRange {
+		return nil, errors.New("simulation range is too long (max 1 year)")
+	}
+
+	clock := NewManualClock(from)
+	s := newScheduler(ctrl, clock)
+
+	var reminders []SimulatedReminder
+	s.record = func(event fireEvent, now time.Time) {
+		if now.Before(from) {
+			return
+		}
+		reminder := simulated(event)
+		// Snooze yang waktunya sudah lewat di-fire saat itu juga, seperti scheduler sungguhan
+		if reminder.FireAt.Before(now) {
+			reminder.FireAt = now
+		}
+		if event.Kind == eventSnooze {
+			if original, err := ctrl.History.GetEventById(event.Snooze.EventId); err == nil {
+				reminder.AnimeTitle = original.AnimeTitle
+			}
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	s.rebuild(from)
+	for {
+		event, ok := s.queue.peek()
+		if !ok || event.At.After(to) {
+			break
+		}
+		// Event yang sudah lewat (snooze) tidak boleh memundurkan jam
+		at := event.At
+		if at.Before(clock.Now()) {
+			at = clock.Now()
+		}
+		clock.Set(at)
+		s.fireDue(at)
+	}
+	return reminders, nil
+}
+
+// simulated mengubah event antrian menjadi SimulatedReminder
+func simulated(event fireEvent) SimulatedReminder {
+	reminder := SimulatedReminder{FireAt: event.At}
+	switch event.Kind {
+	case eventAiring:
+		reminder.Kind = "airing"
+	case eventAdvance:
+		reminder.Kind = "advance"
+		reminder.OffsetMinutes = event.Offset.Minutes
+	case eventSnooze:
+		reminder.Kind = "snooze"
+		reminder.AnimeId = event.Snooze.AnimeId
+		return reminder
+	case eventDigest:
+		reminder.Kind = "digest"
+		reminder.AnimeTitle = event.Digest.String()
+		return reminder
+	}
+
+	reminder.AnimeId = event.Airing.Anime.Id
+	reminder.AnimeTitle = event.Airing.Anime.Title
+	reminder.SlotLabel = event.Airing.Slot.Label
+	reminder.AirAt = event.Airing.At
+	return reminder
+}
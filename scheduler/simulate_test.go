@@ -0,0 +1,261 @@
+package scheduler
+
+import (
+	"anime-reminder/controllers"
+	"anime-reminder/models"
+	"anime-reminder/store"
+	"testing"
+	"time"
+)
+
+// expectedReminder adalah satu baris hasil Simulate yang diharapkan, At dalam RFC 3339
+type expectedReminder struct {
+	Kind  string
+	Title string
+	At    string
+}
+
+func clockAt(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func newSlot(label string, day models.Weekday, hour, minute int) models.ScheduleSlot {
+	return models.ScheduleSlot{Label: label, Day: day, Time: clockAt(hour, minute), Enabled: true}
+}
+
+func lateNightSlot(t *testing.T, day models.Weekday, hour, minute int) models.ScheduleSlot {
+	t.Helper()
+	s, err := models.NewScheduleSlot("TV", day, hour, minute)
+	if err != nil {
+		t.Fatalf("NewScheduleSlot: %v", err)
+	}
+	s.Enabled = true
+	return s
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	return &d
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone data not available: %v", err)
+	}
+	return loc
+}
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name string
+		// local adalah zona waktu lokal selama simulasi
+		local  string
+		animes func(t *testing.T) []models.Anime
+		// snoozes adalah Until (RFC 3339) snooze yang masih menunggu, milik anime pertama
+		snoozes []string
+		from    string
+		to      string
+		want    []expectedReminder
+	}{
+		{
+			name:  "midnight rollover",
+			local: "UTC",
+			animes: func(t *testing.T) []models.Anime {
+				offsets := []models.ReminderOffset{{Minutes: 10}}
+				return []models.Anime{
+					{Title: "Frieren", Slots: []models.ScheduleSlot{newSlot("TV", models.Monday, 23, 55)}, ReminderOffsets: offsets},
+					{Title: "Dandadan", Slots: []models.ScheduleSlot{newSlot("TV", models.Tuesday, 0, 5)}, ReminderOffsets: offsets},
+				}
+			},
+			from: "2026-10-19T22:00:00Z",
+			to:   "2026-10-20T02:00:00Z",
+			want: []expectedReminder{
+				{Kind: "advance", Title: "Frieren", At: "2026-10-19T23:45:00Z"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-19T23:55:00Z"},
+				// Advance reminder sebelum tengah malam untuk tayangan hari berikutnya
+				{Kind: "advance", Title: "Dandadan", At: "2026-10-19T23:55:00Z"},
+				{Kind: "airing", Title: "Dandadan", At: "2026-10-20T00:05:00Z"},
+			},
+		},
+		{
+			name:  "DST change",
+			local: "America/New_York",
+			animes: func(t *testing.T) []models.Anime {
+				return []models.Anime{
+					// Jam JST tetap, jam lokal mundur satu jam setelah DST berakhir
+					{Title: "Frieren", Timezone: "Asia/Tokyo", Slots: []models.ScheduleSlot{newSlot("TV", models.Sunday, 16, 0)}},
+					// Jam lokal tetap, jam UTC bergeser
+					{Title: "Dandadan", Slots: []models.ScheduleSlot{newSlot("Dub", models.Sunday, 4, 0)}},
+				}
+			},
+			from: "2026-10-24T12:00:00-04:00",
+			to:   "2026-11-02T12:00:00-05:00",
+			want: []expectedReminder{
+				{Kind: "airing", Title: "Frieren", At: "2026-10-25T03:00:00-04:00"},
+				{Kind: "airing", Title: "Dandadan", At: "2026-10-25T04:00:00-04:00"},
+				{Kind: "airing", Title: "Frieren", At: "2026-11-01T02:00:00-05:00"},
+				{Kind: "airing", Title: "Dandadan", At: "2026-11-01T04:00:00-05:00"},
+			},
+		},
+		{
+			name:  "JST 25:30 slot",
+			local: "UTC",
+			animes: func(t *testing.T) []models.Anime {
+				return []models.Anime{
+					{Title: "Frieren", Timezone: "Asia/Tokyo", Slots: []models.ScheduleSlot{lateNightSlot(t, models.Saturday, 25, 30)}},
+				}
+			},
+			from: "2026-10-16T00:00:00Z",
+			to:   "2026-10-25T00:00:00Z",
+			want: []expectedReminder{
+				// Sabtu 25:30 JST = Minggu 01:30 JST = Sabtu 16:30 UTC
+				{Kind: "airing", Title: "Frieren", At: "2026-10-17T16:30:00Z"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-24T16:30:00Z"},
+			},
+		},
+		{
+			name:  "season finale day",
+			local: "UTC",
+			animes: func(t *testing.T) []models.Anime {
+				return []models.Anime{{
+					Title:  "Frieren",
+					Slots:  []models.ScheduleSlot{newSlot("TV", models.Friday, 23, 0)},
+					Season: models.Season{PremiereDate: date(2026, 10, 9), EpisodeCount: 2},
+				}}
+			},
+			from: "2026-10-01T00:00:00Z",
+			to:   "2026-10-31T00:00:00Z",
+			want: []expectedReminder{
+				{Kind: "airing", Title: "Frieren", At: "2026-10-09T23:00:00Z"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-16T23:00:00Z"},
+			},
+		},
+		{
+			name:  "premiere and finale across timezones",
+			local: "America/Los_Angeles",
+			animes: func(t *testing.T) []models.Anime {
+				// Sabtu 01:00 JST = Jumat 09:00 PDT; tanggal musim adalah tanggal siaran (Sabtu)
+				return []models.Anime{{
+					Title:    "Frieren",
+					Timezone: "Asia/Tokyo",
+					Slots:    []models.ScheduleSlot{newSlot("TV", models.Saturday, 1, 0)},
+					Season:   models.Season{PremiereDate: date(2026, 10, 3), EpisodeCount: 2},
+				}}
+			},
+			from: "2026-09-26T00:00:00-07:00",
+			to:   "2026-10-24T00:00:00-07:00",
+			want: []expectedReminder{
+				{Kind: "airing", Title: "Frieren", At: "2026-10-02T09:00:00-07:00"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-09T09:00:00-07:00"},
+			},
+		},
+		{
+			name:  "pending snoozes",
+			local: "UTC",
+			animes: func(t *testing.T) []models.Anime {
+				return []models.Anime{{Title: "Frieren", Slots: []models.ScheduleSlot{newSlot("TV", models.Friday, 23, 0)}}}
+			},
+			// Snooze pertama sudah lewat (aplikasi sempat ditutup): di-fire saat mulai, sekali saja
+			snoozes: []string{"2026-10-18T20:00:00Z", "2026-10-20T12:00:00Z"},
+			from:    "2026-10-19T00:00:00Z",
+			to:      "2026-10-26T00:00:00Z",
+			want: []expectedReminder{
+				{Kind: "snooze", Title: "Frieren", At: "2026-10-19T00:00:00Z"},
+				{Kind: "snooze", Title: "Frieren", At: "2026-10-20T12:00:00Z"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-23T23:00:00Z"},
+			},
+		},
+		{
+			name:  "advance offsets",
+			local: "UTC",
+			animes: func(t *testing.T) []models.Anime {
+				return []models.Anime{{
+					Title:           "Frieren",
+					Slots:           []models.ScheduleSlot{newSlot("TV", models.Friday, 23, 0)},
+					ReminderOffsets: []models.ReminderOffset{{Minutes: 60}, {Minutes: 15}},
+				}}
+			},
+			from: "2026-10-23T00:00:00Z",
+			to:   "2026-10-24T00:00:00Z",
+			want: []expectedReminder{
+				{Kind: "advance", Title: "Frieren", At: "2026-10-23T22:00:00Z"},
+				{Kind: "advance", Title: "Frieren", At: "2026-10-23T22:45:00Z"},
+				{Kind: "airing", Title: "Frieren", At: "2026-10-23T23:00:00Z"},
+			},
+		},
+	}
+
+	originalLocal := time.Local
+	defer func() { time.Local = originalLocal }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			time.Local = mustLoadLocation(t, tt.local)
+
+			s := store.NewMemoryStore()
+			var animes []models.Anime
+			for _, anime := range tt.animes(t) {
+				if err := s.CreateAnime(&anime); err != nil {
+					t.Fatalf("CreateAnime: %v", err)
+				}
+				animes = append(animes, anime)
+			}
+			for _, until := range tt.snoozes {
+				untilAt := mustParseTime(t, until)
+				event := models.ReminderEvent{AnimeId: animes[0].Id, AnimeTitle: animes[0].Title, FiredAt: untilAt.Add(-10 * time.Minute)}
+				if err := s.CreateReminderEvent(&event); err != nil {
+					t.Fatalf("CreateReminderEvent: %v", err)
+				}
+				if err := s.CreateSnooze(&models.Snooze{EventId: event.Id, AnimeId: animes[0].Id, Until: untilAt}); err != nil {
+					t.Fatalf("CreateSnooze: %v", err)
+				}
+			}
+			ctrl := controllers.NewControllers(s)
+
+			from := mustParseTime(t, tt.from)
+			to := mustParseTime(t, tt.to)
+			got, err := Simulate(ctrl, from, to)
+			if err != nil {
+				t.Fatalf("Simulate: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				for _, reminder := range got {
+					t.Logf("got %s %s at %s", reminder.Kind, reminder.AnimeTitle, reminder.FireAt.Format(time.RFC3339))
+				}
+				t.Fatalf("got %d reminders, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				reminder := got[i]
+				if reminder.Kind != want.Kind || reminder.AnimeTitle != want.Title || !reminder.FireAt.Equal(mustParseTime(t, want.At)) {
+					t.Errorf("reminder %d = %s %s at %s, want %s %s at %s", i,
+						reminder.Kind, reminder.AnimeTitle, reminder.FireAt.Format(time.RFC3339),
+						want.Kind, want.Title, want.At)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateRejectsInvalidRange(t *testing.T) {
+	ctrl := controllers.NewControllers(store.NewMemoryStore())
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	if _, err := Simulate(ctrl, from, from); err == nil {
+		t.Error("Simulate accepted an empty range")
+	}
+	if _, err := Simulate(ctrl, from, from.Add(MaxSimulationRange+time.Hour)); err == nil {
+		t.Error("Simulate accepted a range longer than MaxSimulationRange")
+	}
+}
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+	return at
+}
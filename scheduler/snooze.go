@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"anime-reminder/controllers"
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dismissAction      = "dismiss"
+	watchedAction      = "watched"
+	snoozeActionPrefix = "snooze-"
+
+	// notificationSnooze adalah snooze dari tombol di notifikasi,
+	// pilihan lain tersedia di popup dan tray
+	notificationSnooze = 10 * time.Minute
+)
+
+// openListeners dipanggil saat user membuka aplikasi dari notifikasi
+var openListeners struct {
+	mu  sync.Mutex
+	fns []func(animeID uint)
+}
+
+// OnOpen mendaftarkan callback untuk tombol "Open" / klik pada notifikasi,
+// dipanggil dari goroutine lain
+func OnOpen(fn func(animeID uint)) {
+	openListeners.mu.Lock()
+	defer openListeners.mu.Unlock()
+	openListeners.fns = append(openListeners.fns, fn)
+}
+
+func notifyOpen(animeID uint) {
+	openListeners.mu.Lock()
+	fns := append([]func(uint){}, openListeners.fns...)
+	openListeners.mu.Unlock()
+	for _, fn := range fns {
+		fn(animeID)
+	}
+}
+
+// active menyimpan reminder terakhir yang bisa di-snooze/dismiss dari tray dan popup
+var active struct {
+	mu        sync.Mutex
+	event     *models.ReminderEvent
+	listeners []func(models.ReminderEvent)
+}
+
+// OnReminder mendaftarkan callback yang dipanggil (dari goroutine scheduler)
+// setiap kali reminder yang bisa di-snooze di-fire, misalnya untuk popup di UI
+func OnReminder(fn func(event models.ReminderEvent)) {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	active.listeners = append(active.listeners, fn)
+}
+
+// ActiveReminder returns the last reminder that has not been dismissed yet
+func ActiveReminder() (models.ReminderEvent, bool) {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	if active.event == nil {
+		return models.ReminderEvent{}, false
+	}
+	return *active.event, true
+}
+
+// setActive menjadikan event reminder aktif lalu memberi tahu listener
+func setActive(event models.ReminderEvent) {
+	active.mu.Lock()
+	active.event = &event
+	listeners := append([]func(models.ReminderEvent){}, active.listeners...)
+	active.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(event)
+	}
+}
+
+// clearActive menghapus reminder aktif jika masih event yang sama
+func clearActive(eventID uint) {
+	active.mu.Lock()
+	defer active.mu.Unlock()
+	if active.event != nil && active.event.Id == eventID {
+		active.event = nil
+	}
+}
+
+// reminderActions adalah tombol di notifikasi: snooze, watched (jika episodenya
+// diketahui), open dan dismiss
+func reminderActions(event models.ReminderEvent) []notifier.Action {
+	minutes := int(notificationSnooze / time.Minute)
+	actions := []notifier.Action{{
+		Key:   fmt.Sprintf("%s%d", snoozeActionPrefix, minutes),
+		Label: fmt.Sprintf("Snooze %d min", minutes),
+	}}
+	if event.EpisodeNumber > 0 {
+		actions = append(actions, notifier.Action{Key: watchedAction, Label: "Watched"})
+	}
+	return append(actions,
+		notifier.Action{Key: notifier.ActionOpen, Label: "Open"},
+		notifier.Action{Key: dismissAction, Label: "Dismiss"},
+	)
+}
+
+// airingTag mengelompokkan notifikasi satu tayangan supaya hitung mundur
+// advance reminder dan reminder tayang saling menggantikan
+func airingTag(event models.ReminderEvent) string {
+	if event.AirDate == "" {
+		return ""
+	}
+	return fmt.Sprintf("airing-%d-%d-%s", event.AnimeId, event.SlotId, event.AirDate)
+}
+
+// handleAction menjalankan tombol yang diklik di notifikasi
+func handleAction(ctrl *controllers.Controllers, eventID uint, key string) {
+	var err error
+	switch {
+	case key == dismissAction:
+		err = DismissReminder(ctrl, eventID)
+	case key == watchedAction:
+		err = MarkWatched(ctrl, eventID)
+	case key == notifier.ActionOpen:
+		if event, getErr := ctrl.History.GetEventById(eventID); getErr == nil {
+			notifyOpen(event.AnimeId)
+		}
+	case strings.HasPrefix(key, snoozeActionPrefix):
+		minutes, parseErr := strconv.Atoi(strings.TrimPrefix(key, snoozeActionPrefix))
+		if parseErr != nil {
+			// Bukan tombol snooze dari reminderActions
+			return
+		}
+		_, err = SnoozeReminder(ctrl, eventID, time.Duration(minutes)*time.Minute)
+	default:
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️ Notification action %s failed: %v", key, err)
+	}
+}
+
+// withActions menambahkan tombol snooze/dismiss ke notifikasi n (lihat reminderNotification).
+// Tombol menunjuk ke event.Id, yang terisi setelah event disimpan ke history.
+func withActions(ctrl *controllers.Controllers, event *models.ReminderEvent, n notifier.Notification) notifier.Notification {
+	n.Actions = reminderActions(*event)
+	n.OnAction = func(key string) {
+		handleAction(ctrl, event.Id, key)
+	}
+	return n
+}
+
+// SnoozeReminder menghentikan ringtone dan menjadwalkan ulang reminder setelah d
+func SnoozeReminder(ctrl *controllers.Controllers, eventID uint, d time.Duration) (*models.Snooze, error) {
+	event, err := ctrl.History.GetEventById(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	snooze, err := ctrl.Snooze.Snooze(*event, d)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.StopGlobalPlayer()
+	clearActive(eventID)
+	log.Printf("💤 Snoozed %s until %s", event.AnimeTitle, snooze.Until.Format("15:04"))
+	return snooze, nil
+}
+
+// DismissReminder menghentikan ringtone dan membatalkan snooze yang masih menunggu
+func DismissReminder(ctrl *controllers.Controllers, eventID uint) error {
+	utils.StopGlobalPlayer()
+	clearActive(eventID)
+	if err := ctrl.Snooze.Dismiss(eventID); err != nil {
+		return err
+	}
+
+	// Tandai tayangannya sudah dilihat user, supaya tidak di-fire lagi setelah restart
+	if event, err := ctrl.History.GetEventById(eventID); err == nil && event.AirDate != "" {
+		if err := ctrl.Airing.MarkAcknowledged(*event, time.Now()); err != nil {
+			log.Printf("⚠️ Failed to save reminder state: %v", err)
+		}
+	}
+	log.Printf("👋 Reminder dismissed (event %d)", eventID)
+	return nil
+}
+
+// MarkWatched menandai episode dari reminder sebagai sudah ditonton lalu menutup reminder-nya
+func MarkWatched(ctrl *controllers.Controllers, eventID uint) error {
+	event, err := ctrl.History.GetEventById(eventID)
+	if err != nil {
+		return err
+	}
+	if event.EpisodeNumber == 0 {
+		return fmt.Errorf("reminder has no episode")
+	}
+
+	episodes, err := ctrl.Episode.GetEpisodes(event.AnimeId)
+	if err != nil {
+		return err
+	}
+	for i := range episodes {
+		if episodes[i].Number != event.EpisodeNumber {
+			continue
+		}
+		if err := ctrl.Episode.SetWatched(&episodes[i], true); err != nil {
+			return err
+		}
+		log.Printf("✅ Marked as watched: %s episode %d", event.AnimeTitle, event.EpisodeNumber)
+		return DismissReminder(ctrl, eventID)
+	}
+	return fmt.Errorf("episode %d not found", event.EpisodeNumber)
+}
+
+// fireSnooze mengirim ulang reminder yang di-snooze, lengkap dengan ringtone slot-nya
+func fireSnooze(ctrl *controllers.Controllers, snooze models.Snooze, now time.Time) {
+	// Snooze bisa saja sudah di-dismiss sebelum antrian disusun ulang
+	if !snoozePending(ctrl, snooze.Id) {
+		return
+	}
+	if err := ctrl.Snooze.Done(snooze.Id); err != nil {
+		log.Printf("⚠️ Failed to remove snooze: %v", err)
+	}
+
+	original, err := ctrl.History.GetEventById(snooze.EventId)
+	if err != nil {
+		log.Printf("⚠️ Snoozed reminder %d not found: %v", snooze.EventId, err)
+		return
+	}
+
+	title := original.Title
+	if !strings.HasPrefix(title, "💤") {
+		title = "💤 " + title
+	}
+	event := models.ReminderEvent{
+		AnimeId:       original.AnimeId,
+		AnimeTitle:    original.AnimeTitle,
+		SlotId:        original.SlotId,
+		AirDate:       original.AirDate,
+		OffsetMinutes: original.OffsetMinutes,
+		EpisodeNumber: original.EpisodeNumber,
+		Title:         title,
+		Message:       original.Message,
+		FiredAt:       now,
+		ResentFromId:  original.Id,
+	}
+	log.Printf("💤 Snooze over: %s", event.AnimeTitle)
+	if quietSuppressed(now) {
+		suppressedDelivery(&event)
+		logReminder(ctrl, &event)
+		return
+	}
+	// Ringtone dan cover sama seperti reminder aslinya
+	anime, err := ctrl.Anime.GetAnimeById(original.AnimeId)
+	if err != nil {
+		anime = &models.Anime{Id: original.AnimeId, Title: original.AnimeTitle}
+	}
+	n := reminderNotification(*anime, event, episodeLabel(event), time.Time{})
+	n.Tags = []string{"zzz"}
+	n = withActions(ctrl, &event, n)
+	event.Deliveries = deliver(n)
+
+	if err == nil {
+		ringToneId := anime.RingToneId
+		for _, slot := range anime.Slots {
+			if slot.Id == original.SlotId {
+				ringToneId = anime.SlotRingToneId(slot)
+			}
+		}
+		playRingTone(ctrl, ringToneId, 30*time.Second, &event)
+	}
+
+	logAndBroadcast(ctrl, &event, n)
+	setActive(event)
+}
+
+// snoozePending returns true if the snooze is still stored
+func snoozePending(ctrl *controllers.Controllers, id uint) bool {
+	snoozes, err := ctrl.Snooze.Pending()
+	if err != nil {
+		return true
+	}
+	for _, snooze := range snoozes {
+		if snooze.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// playRingTone memutar ringtone dan mencatatnya di event
+func playRingTone(ctrl *controllers.Controllers, ringToneId uint, duration time.Duration, event *models.ReminderEvent) {
+	if ringToneId == 0 {
+		return
+	}
+	ringTone, err := ctrl.RingTone.GetRingToneById(ringToneId)
+	if err != nil {
+		log.Printf("⚠️ Failed to get ringtone: %v", err)
+		return
+	}
+	if ringTone.SongPath == "" {
+		return
+	}
+
+	event.RingToneName = ringTone.Name
+	err = utils.PlayAudio(ringTone.SongPath, duration)
+	if errors.Is(err, utils.ErrAudioMuted) {
+		log.Printf("🔇 Quiet hours, ringtone muted: %s", ringTone.Name)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Failed to play audio: %v", err)
+		return
+	}
+	event.AudioPlayed = true
+	log.Printf("🔊 Playing ringtone: %s", ringTone.Name)
+}
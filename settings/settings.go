@@ -0,0 +1,199 @@
+package settings
+
+import (
+	"anime-reminder/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName adalah nama file pengaturan di dalam data directory
+const FileName = "settings.json"
+
+// fileMode: settings berisi token bot dan password SMTP, hanya boleh dibaca pemiliknya
+const fileMode os.FileMode = 0600
+
+// Settings berisi preferensi aplikasi yang tidak termasuk library anime,
+// sehingga berlaku sama untuk semua storage backend
+type Settings struct {
+	// DayLocale adalah bahasa nama hari di UI ("id", "en", "ja"), kosong berarti ikut locale sistem
+	DayLocale string `json:"day_locale,omitempty"`
+	// MissedGraceMinutes adalah batas keterlambatan reminder yang masih dikirim satu per satu
+	// ("started 12 minutes ago"), lebih lama dari itu masuk ringkasan "you missed". 0 = default.
+	MissedGraceMinutes int `json:"missed_grace_minutes,omitempty"`
+	// DefaultOffsets adalah advance reminder untuk anime yang tidak punya offset sendiri
+	DefaultOffsets []models.ReminderOffset `json:"default_offsets,omitempty"`
+	// QuietHours adalah window per hari di mana reminder tidak bersuara / tidak dikirim
+	QuietHours []models.QuietWindow `json:"quiet_hours,omitempty"`
+	// DailyDigestTime adalah jam digest pagi ("HH:MM"), kosong berarti tidak ada digest harian
+	DailyDigestTime string `json:"daily_digest_time,omitempty"`
+	// WeeklyDigest mengirim daftar tayangan minggu depan setiap Minggu malam
+	WeeklyDigest     bool   `json:"weekly_digest,omitempty"`
+	WeeklyDigestTime string `json:"weekly_digest_time,omitempty"`
+	// DigestTemplate adalah template satu baris digest, kosong = models.DefaultDigestTemplate
+	DigestTemplate string `json:"digest_template,omitempty"`
+	// DisabledNotifiers adalah nama backend notifikasi yang dimatikan user
+	DisabledNotifiers []string `json:"disabled_notifiers,omitempty"`
+	// Webhooks menerima salinan setiap reminder (Discord, Slack, Matrix, ...)
+	Webhooks []models.Webhook `json:"webhooks,omitempty"`
+	// Telegram mengirim reminder ke chat Telegram dengan tombol Watched / Snooze
+	Telegram models.Telegram `json:"telegram"`
+	// Email mengirim reminder (dan digest, jika diaktifkan) lewat SMTP
+	Email models.Email `json:"email"`
+	// Ntfy dan Gotify adalah push ke HP lewat server sendiri, tanpa akun cloud
+	Ntfy   models.Ntfy   `json:"ntfy"`
+	Gotify models.Gotify `json:"gotify"`
+}
+
+// DefaultMissedGrace dipakai jika MissedGraceMinutes belum diatur
+const DefaultMissedGrace = 30 * time.Minute
+
+// MissedGrace returns the grace window for late reminders
+func (s Settings) MissedGrace() time.Duration {
+	if s.MissedGraceMinutes <= 0 {
+		return DefaultMissedGrace
+	}
+	return time.Duration(s.MissedGraceMinutes) * time.Minute
+}
+
+// QuietWindowAt returns the quiet hours window active at now, if any
+func (s Settings) QuietWindowAt(now time.Time) (models.QuietWindow, bool) {
+	return models.ActiveQuietWindow(s.QuietHours, now)
+}
+
+// VolumeAt returns the ringtone volume in percent at now (100 di luar quiet hours)
+func (s Settings) VolumeAt(now time.Time) int {
+	if w, ok := s.QuietWindowAt(now); ok {
+		return w.AudioVolume()
+	}
+	return 100
+}
+
+// WeeklyDigestClock returns the Sunday digest time, default models.DefaultWeeklyDigestTime
+func (s Settings) WeeklyDigestClock() string {
+	if s.WeeklyDigestTime == "" {
+		return models.DefaultWeeklyDigestTime
+	}
+	return s.WeeklyDigestTime
+}
+
+// NotifierEnabled returns true unless the backend was disabled in settings
+func (s Settings) NotifierEnabled(name string) bool {
+	for _, disabled := range s.DisabledNotifiers {
+		if disabled == name {
+			return false
+		}
+	}
+	return true
+}
+
+// SetNotifierEnabled menyalakan / mematikan satu backend notifikasi
+func (s *Settings) SetNotifierEnabled(name string, enabled bool) {
+	disabled := make([]string, 0, len(s.DisabledNotifiers)+1)
+	for _, existing := range s.DisabledNotifiers {
+		if existing != name {
+			disabled = append(disabled, existing)
+		}
+	}
+	if !enabled {
+		disabled = append(disabled, name)
+	}
+	s.DisabledNotifiers = disabled
+}
+
+var (
+	mu      sync.RWMutex
+	path    string
+	current Settings
+)
+
+// Load membaca pengaturan dari file, file yang belum ada berarti pengaturan default
+func Load(filePath string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	path = filePath
+	current = Settings{}
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	// File dari versi lama ditulis dengan 0644
+	if info, err := os.Stat(filePath); err == nil && info.Mode().Perm()&^fileMode != 0 {
+		if err := os.Chmod(filePath, fileMode); err != nil {
+			return fmt.Errorf("failed to restrict permissions of %s: %v", filePath, err)
+		}
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", filePath, err)
+	}
+	return nil
+}
+
+// Get returns a copy of the current settings
+func Get() Settings {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Update mengubah pengaturan lewat fn lalu menyimpannya ke file
+func Update(fn func(s *Settings)) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	updated := current
+	fn(&updated)
+	if err := save(updated); err != nil {
+		return err
+	}
+	current = updated
+	return nil
+}
+
+// save menulis file sementara lalu rename (lock harus sudah dipegang)
+func save(s Settings) error {
+	if path == "" {
+		// Load belum dipanggil, simpan di memory saja
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// CreateTemp membuat file dengan mode 0600
+	tmp, err := os.CreateTemp(filepath.Dir(path), FileName+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, fileMode)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %v", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
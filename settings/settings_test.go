@@ -0,0 +1,50 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSettingsFileIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no Unix file permissions")
+	}
+	filePath := filepath.Join(t.TempDir(), FileName)
+	// File lama yang ditulis versi sebelumnya masih bisa dibaca semua user
+	if err := os.WriteFile(filePath, []byte(`{"day_locale": "en"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Load(filePath); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	t.Cleanup(func() { Load("") })
+	assertMode(t, filePath)
+
+	if err := Update(func(s *Settings) { s.DayLocale = "ja" }); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	assertMode(t, filePath)
+	if err := Load(filePath); err != nil || Get().DayLocale != "ja" {
+		t.Errorf("reloaded day locale = %q (%v), want ja", Get().DayLocale, err)
+	}
+
+	// Tidak ada file sementara yang tertinggal
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(filePath), "*.tmp"))
+	if len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
+
+func assertMode(t *testing.T, filePath string) {
+	t.Helper()
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != fileMode {
+		t.Errorf("settings file mode = %o, want %o", mode, fileMode)
+	}
+}
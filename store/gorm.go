@@ -0,0 +1,266 @@
+package store
+
+import (
+	"anime-reminder/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// GormStore adalah implementasi default yang menyimpan data di SQLite lewat GORM
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore membuat store di atas koneksi GORM yang sudah di-migrate
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// notFound menerjemahkan gorm.ErrRecordNotFound menjadi ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// ===== ANIME =====
+
+// withSlots me-preload slot jadwal setiap anime
+func (s *GormStore) withSlots() *gorm.DB {
+	return s.db.Preload("Slots", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+func (s *GormStore) CreateAnime(anime *models.Anime) error {
+	// Slots ikut dibuat lewat association
+	return s.db.Create(anime).Error
+}
+
+func (s *GormStore) GetAnime(id uint) (*models.Anime, error) {
+	var anime models.Anime
+	if err := s.withSlots().First(&anime, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &anime, nil
+}
+
+func (s *GormStore) GetAnimeByTitle(title string) (*models.Anime, error) {
+	var anime models.Anime
+	if err := s.withSlots().Where("title = ?", title).First(&anime).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &anime, nil
+}
+
+func (s *GormStore) ListAnimes() ([]models.Anime, error) {
+	var animes []models.Anime
+	if err := s.withSlots().Order("id").Find(&animes).Error; err != nil {
+		return nil, err
+	}
+	return animes, nil
+}
+
+// UpdateAnime menyimpan anime dan menyamakan slot di database dengan anime.Slots:
+// slot lama di-update (id tetap), slot baru di-insert, slot yang hilang dihapus
+func (s *GormStore) UpdateAnime(anime *models.Anime) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Slots").Save(anime).Error; err != nil {
+			return err
+		}
+
+		keepIDs := make([]uint, 0, len(anime.Slots))
+		for i := range anime.Slots {
+			anime.Slots[i].AnimeId = anime.Id
+			if err := tx.Save(&anime.Slots[i]).Error; err != nil {
+				return err
+			}
+			keepIDs = append(keepIDs, anime.Slots[i].Id)
+		}
+
+		query := tx.Where("anime_id = ?", anime.Id)
+		if len(keepIDs) > 0 {
+			query = query.Where("id NOT IN ?", keepIDs)
+		}
+		return query.Delete(&models.ScheduleSlot{}).Error
+	})
+}
+
+func (s *GormStore) DeleteAnime(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("anime_id = ?", id).Delete(&models.Episode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("anime_id = ?", id).Delete(&models.ScheduleSlot{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("anime_id = ?", id).Delete(&models.Snooze{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("anime_id = ?", id).Delete(&models.AiringState{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Anime{}, id).Error
+	})
+}
+
+// ===== RINGTONE =====
+
+func (s *GormStore) CreateRingTone(ringTone *models.RingTone) error {
+	return s.db.Create(ringTone).Error
+}
+
+func (s *GormStore) GetRingTone(id uint) (*models.RingTone, error) {
+	var ringTone models.RingTone
+	if err := s.db.First(&ringTone, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &ringTone, nil
+}
+
+func (s *GormStore) ListRingTones() ([]models.RingTone, error) {
+	var ringTones []models.RingTone
+	if err := s.db.Order("id").Find(&ringTones).Error; err != nil {
+		return nil, err
+	}
+	return ringTones, nil
+}
+
+func (s *GormStore) UpdateRingTone(ringTone *models.RingTone) error {
+	return s.db.Save(ringTone).Error
+}
+
+func (s *GormStore) DeleteRingTone(id uint) error {
+	return s.db.Delete(&models.RingTone{}, id).Error
+}
+
+// ===== EPISODE =====
+
+func (s *GormStore) ReplaceEpisodes(animeID uint, episodes []models.Episode) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("anime_id = ?", animeID).Delete(&models.Episode{}).Error; err != nil {
+			return err
+		}
+		if len(episodes) == 0 {
+			return nil
+		}
+		for i := range episodes {
+			episodes[i].Id = 0
+			episodes[i].AnimeId = animeID
+		}
+		return tx.Create(&episodes).Error
+	})
+}
+
+func (s *GormStore) ListEpisodes(animeID uint) ([]models.Episode, error) {
+	var episodes []models.Episode
+	if err := s.db.Where("anime_id = ?", animeID).Order("number").Find(&episodes).Error; err != nil {
+		return nil, err
+	}
+	return episodes, nil
+}
+
+func (s *GormStore) UpdateEpisode(episode *models.Episode) error {
+	return s.db.Save(episode).Error
+}
+
+// ===== HISTORY =====
+
+func (s *GormStore) CreateReminderEvent(event *models.ReminderEvent) error {
+	return s.db.Create(event).Error
+}
+
+func (s *GormStore) GetReminderEvent(id uint) (*models.ReminderEvent, error) {
+	var event models.ReminderEvent
+	if err := s.db.Preload("Deliveries").First(&event, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &event, nil
+}
+
+func (s *GormStore) ListReminderEvents(filter models.ReminderEventFilter) ([]models.ReminderEvent, error) {
+	query := s.db.Preload("Deliveries").Order("fired_at DESC, id DESC")
+	if filter.AnimeId != 0 {
+		query = query.Where("anime_id = ?", filter.AnimeId)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("fired_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("fired_at < ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []models.ReminderEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *GormStore) AddReminderDeliveries(eventID uint, deliveries []models.ReminderDelivery) error {
+	if _, err := s.GetReminderEvent(eventID); err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	for i := range deliveries {
+		deliveries[i].EventId = eventID
+	}
+	return s.db.Create(&deliveries).Error
+}
+
+// ===== SNOOZE =====
+
+func (s *GormStore) CreateSnooze(snooze *models.Snooze) error {
+	return s.db.Create(snooze).Error
+}
+
+func (s *GormStore) ListSnoozes() ([]models.Snooze, error) {
+	var snoozes []models.Snooze
+	if err := s.db.Order("until, id").Find(&snoozes).Error; err != nil {
+		return nil, err
+	}
+	return snoozes, nil
+}
+
+func (s *GormStore) DeleteSnooze(id uint) error {
+	return s.db.Delete(&models.Snooze{}, id).Error
+}
+
+func (s *GormStore) DeleteEventSnoozes(eventID uint) error {
+	return s.db.Where("event_id = ?", eventID).Delete(&models.Snooze{}).Error
+}
+
+// ===== AIRING STATE =====
+
+func (s *GormStore) GetAiringState(animeID, slotID uint, airDate string, offsetMinutes int) (*models.AiringState, error) {
+	var state models.AiringState
+	err := s.db.Where("anime_id = ? AND slot_id = ? AND air_date = ? AND offset_minutes = ?", animeID, slotID, airDate, offsetMinutes).
+		First(&state).Error
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &state, nil
+}
+
+func (s *GormStore) SaveAiringState(state *models.AiringState) error {
+	return s.db.Save(state).Error
+}
+
+func (s *GormStore) ListAiringStates(since string) ([]models.AiringState, error) {
+	var states []models.AiringState
+	if err := s.db.Where("air_date >= ?", since).Order("air_date, id").Find(&states).Error; err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+func (s *GormStore) DeleteAiringStatesBefore(before string) error {
+	return s.db.Where("air_date < ?", before).Delete(&models.AiringState{}).Error
+}
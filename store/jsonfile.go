@@ -0,0 +1,182 @@
+package store
+
+import (
+	"anime-reminder/models"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JSONFileStore menyimpan library dalam satu file JSON yang rapi (indented),
+// sehingga mudah di-diff dan di-commit ke git. Data runtime (riwayat reminder,
+// snooze dan status airing) disimpan terpisah di file state di sebelahnya,
+// misalnya library.state.json, supaya library tidak berubah setiap kali
+// reminder di-fire. Data dibaca sekali saat dibuka, lalu file yang isinya
+// berubah ditulis ulang secara atomik setiap kali ada perubahan.
+type JSONFileStore struct {
+	*MemoryStore
+	path      string
+	statePath string
+
+	// written adalah isi terakhir setiap file di disk, untuk melewati penulisan yang sama
+	written map[string][]byte
+}
+
+// jsonLibrary adalah format file library di disk
+type jsonLibrary struct {
+	Animes    []models.Anime    `json:"animes"`
+	RingTones []models.RingTone `json:"ring_tones"`
+	Episodes  []models.Episode  `json:"episodes"`
+}
+
+// jsonState adalah format file state di disk. Library versi lama menyimpan
+// field ini di file library, jadi keduanya dibaca dengan struct yang sama.
+type jsonState struct {
+	Events  []models.ReminderEvent `json:"reminder_events"`
+	Snoozes []models.Snooze        `json:"snoozes"`
+	States  []models.AiringState   `json:"airing_states"`
+}
+
+// NewJSONFileStore membuka (atau membuat) library JSON di path
+func NewJSONFileStore(path string) (*JSONFileStore, error) {
+	s := &JSONFileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+		statePath:   strings.TrimSuffix(path, filepath.Ext(path)) + ".state.json",
+		written:     make(map[string][]byte),
+	}
+
+	var lib jsonLibrary
+	var legacy jsonState
+	if _, err := s.read(path, &lib, &legacy); err != nil {
+		return nil, err
+	}
+	var state jsonState
+	found, err := s.read(s.statePath, &state)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// Library versi lama menyimpan data runtime di file library; data itu
+		// pindah ke file state pada penyimpanan berikutnya
+		state = legacy
+	}
+
+	for i := range lib.Animes {
+		s.animes.insert(&lib.Animes[i])
+		for _, slot := range lib.Animes[i].Slots {
+			if slot.Id > s.slotSeq {
+				s.slotSeq = slot.Id
+			}
+		}
+	}
+	for i := range lib.RingTones {
+		s.ringTones.insert(&lib.RingTones[i])
+	}
+	for i := range lib.Episodes {
+		s.episodes.insert(&lib.Episodes[i])
+	}
+	for i := range state.Events {
+		s.events.insert(&state.Events[i])
+	}
+	for i := range state.Snoozes {
+		s.snoozes.insert(&state.Snoozes[i])
+	}
+	for i := range state.States {
+		s.states.insert(&state.States[i])
+	}
+
+	s.MemoryStore.persist = s.save
+	return s, nil
+}
+
+// read mem-parse file JSON di path ke setiap target. Returns false jika file belum ada.
+func (s *JSONFileStore) read(path string, targets ...interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	for _, target := range targets {
+		if err := json.Unmarshal(data, target); err != nil {
+			return false, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+	}
+	s.written[path] = data
+	return true, nil
+}
+
+// Path returns the location of the JSON library file
+func (s *JSONFileStore) Path() string {
+	return s.path
+}
+
+// StatePath returns the location of the JSON file with runtime data
+func (s *JSONFileStore) StatePath() string {
+	return s.statePath
+}
+
+// save menulis library dan state yang berubah. Keduanya ditulis ke file
+// sementara dulu, baru di-rename, supaya file tidak pernah setengah jadi.
+func (s *JSONFileStore) save() error {
+	files := []struct {
+		path string
+		data interface{}
+	}{
+		{s.path, jsonLibrary{
+			Animes:    s.animes.list(),
+			RingTones: s.ringTones.list(),
+			Episodes:  s.episodes.list(),
+		}},
+		{s.statePath, jsonState{
+			Events:  s.events.list(),
+			Snoozes: s.snoozes.list(),
+			States:  s.states.list(),
+		}},
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	pending := make(map[string][]byte)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+		if bytes.Equal(data, s.written[file.path]) {
+			continue
+		}
+
+		tmpPath := file.path + ".tmp"
+		if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+			removeTemp(pending)
+			return fmt.Errorf("failed to write %s: %v", tmpPath, err)
+		}
+		pending[file.path] = data
+	}
+
+	for path, data := range pending {
+		if err := os.Rename(path+".tmp", path); err != nil {
+			removeTemp(pending)
+			return fmt.Errorf("failed to replace %s: %v", path, err)
+		}
+		s.written[path] = data
+		delete(pending, path)
+	}
+	return nil
+}
+
+// removeTemp menghapus file sementara yang belum di-rename
+func removeTemp(pending map[string][]byte) {
+	for path := range pending {
+		os.Remove(path + ".tmp")
+	}
+}
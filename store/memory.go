@@ -0,0 +1,552 @@
+package store
+
+import (
+	"anime-reminder/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// table adalah tabel in-memory sederhana dengan auto increment id
+type table[T any] struct {
+	rows   map[uint]T
+	nextID uint
+	id     func(*T) *uint
+}
+
+func newTable[T any](id func(*T) *uint) *table[T] {
+	return &table[T]{rows: make(map[uint]T), id: id}
+}
+
+func (t *table[T]) insert(row *T) {
+	idPtr := t.id(row)
+	if *idPtr == 0 {
+		t.nextID++
+		*idPtr = t.nextID
+	} else if *idPtr > t.nextID {
+		t.nextID = *idPtr
+	}
+	t.rows[*idPtr] = *row
+}
+
+func (t *table[T]) get(id uint) (T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *table[T]) update(row *T) bool {
+	id := *t.id(row)
+	if _, ok := t.rows[id]; !ok {
+		return false
+	}
+	t.rows[id] = *row
+	return true
+}
+
+func (t *table[T]) delete(id uint) {
+	delete(t.rows, id)
+}
+
+// clone menyalin tabel; row disimpan sebagai value sehingga salinan map sudah cukup
+func (t *table[T]) clone() *table[T] {
+	rows := make(map[uint]T, len(t.rows))
+	for id, row := range t.rows {
+		rows[id] = row
+	}
+	return &table[T]{rows: rows, nextID: t.nextID, id: t.id}
+}
+
+// list mengembalikan semua row urut berdasarkan id
+func (t *table[T]) list() []T {
+	ids := make([]uint, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	rows := make([]T, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+// MemoryStore menyimpan semua data di memory, cocok untuk testing atau mode tanpa disk
+type MemoryStore struct {
+	mu        sync.RWMutex
+	animes    *table[models.Anime]
+	ringTones *table[models.RingTone]
+	episodes  *table[models.Episode]
+	events    *table[models.ReminderEvent]
+	snoozes   *table[models.Snooze]
+	states    *table[models.AiringState]
+
+	// slotSeq adalah auto increment untuk ScheduleSlot yang disimpan di dalam Anime
+	slotSeq uint
+
+	// persist dipanggil (dengan lock masih dipegang) setelah setiap perubahan data
+	persist func() error
+}
+
+// NewMemoryStore membuat store in-memory yang kosong
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		animes:    newTable(func(a *models.Anime) *uint { return &a.Id }),
+		ringTones: newTable(func(r *models.RingTone) *uint { return &r.Id }),
+		episodes:  newTable(func(e *models.Episode) *uint { return &e.Id }),
+		events:    newTable(func(e *models.ReminderEvent) *uint { return &e.Id }),
+		snoozes:   newTable(func(s *models.Snooze) *uint { return &s.Id }),
+		states:    newTable(func(s *models.AiringState) *uint { return &s.Id }),
+	}
+}
+
+// memorySnapshot adalah isi store sebelum mutasi, dipakai untuk rollback
+type memorySnapshot struct {
+	animes    *table[models.Anime]
+	ringTones *table[models.RingTone]
+	episodes  *table[models.Episode]
+	events    *table[models.ReminderEvent]
+	snoozes   *table[models.Snooze]
+	states    *table[models.AiringState]
+	slotSeq   uint
+}
+
+// snapshot dipanggil sebelum mutasi, dengan lock sudah dipegang. Tanpa persist
+// tidak ada yang bisa gagal, jadi tidak perlu menyalin apa pun.
+func (s *MemoryStore) snapshot() *memorySnapshot {
+	if s.persist == nil {
+		return nil
+	}
+	return &memorySnapshot{
+		animes:    s.animes.clone(),
+		ringTones: s.ringTones.clone(),
+		episodes:  s.episodes.clone(),
+		events:    s.events.clone(),
+		snoozes:   s.snoozes.clone(),
+		states:    s.states.clone(),
+		slotSeq:   s.slotSeq,
+	}
+}
+
+// changed dipanggil setelah mutasi, dengan lock masih dipegang. Jika persist
+// gagal, isi store dikembalikan ke prev supaya memory tetap sama dengan disk.
+func (s *MemoryStore) changed(prev *memorySnapshot) error {
+	if s.persist == nil {
+		return nil
+	}
+	if err := s.persist(); err != nil {
+		s.animes = prev.animes
+		s.ringTones = prev.ringTones
+		s.episodes = prev.episodes
+		s.events = prev.events
+		s.snoozes = prev.snoozes
+		s.states = prev.states
+		s.slotSeq = prev.slotSeq
+		return err
+	}
+	return nil
+}
+
+// ===== ANIME =====
+
+// assignSlotIDs memberi id ke slot baru (lock harus sudah dipegang)
+func (s *MemoryStore) assignSlotIDs(anime *models.Anime, now time.Time) {
+	for i := range anime.Slots {
+		slot := &anime.Slots[i]
+		slot.AnimeId = anime.Id
+		if slot.Id == 0 {
+			s.slotSeq++
+			slot.Id = s.slotSeq
+			slot.CreatedAt = now
+		} else if slot.Id > s.slotSeq {
+			s.slotSeq = slot.Id
+		}
+		slot.UpdatedAt = now
+	}
+}
+
+func (s *MemoryStore) CreateAnime(anime *models.Anime) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	now := time.Now()
+	if anime.CreatedAt.IsZero() {
+		anime.CreatedAt = now
+	}
+	anime.UpdatedAt = now
+
+	row := anime.Clone()
+	s.animes.insert(&row)
+	anime.Id = row.Id
+
+	s.assignSlotIDs(anime, now)
+	row = anime.Clone()
+	s.animes.update(&row)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) GetAnime(id uint) (*models.Anime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	anime, ok := s.animes.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	anime = anime.Clone()
+	return &anime, nil
+}
+
+func (s *MemoryStore) GetAnimeByTitle(title string) (*models.Anime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, anime := range s.animes.list() {
+		if anime.Title == title {
+			anime = anime.Clone()
+			return &anime, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) ListAnimes() ([]models.Anime, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	animes := s.animes.list()
+	for i := range animes {
+		animes[i] = animes[i].Clone()
+	}
+	return animes, nil
+}
+
+func (s *MemoryStore) UpdateAnime(anime *models.Anime) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	if _, ok := s.animes.get(anime.Id); !ok {
+		return ErrNotFound
+	}
+
+	now := time.Now()
+	anime.UpdatedAt = now
+	s.assignSlotIDs(anime, now)
+
+	row := anime.Clone()
+	s.animes.update(&row)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) DeleteAnime(id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	s.deleteEpisodes(id)
+	for _, snooze := range s.snoozes.list() {
+		if snooze.AnimeId == id {
+			s.snoozes.delete(snooze.Id)
+		}
+	}
+	for _, state := range s.states.list() {
+		if state.AnimeId == id {
+			s.states.delete(state.Id)
+		}
+	}
+	s.animes.delete(id)
+	return s.changed(prev)
+}
+
+// ===== RINGTONE =====
+
+func (s *MemoryStore) CreateRingTone(ringTone *models.RingTone) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	now := time.Now()
+	if ringTone.CreatedAt.IsZero() {
+		ringTone.CreatedAt = now
+	}
+	ringTone.UpdatedAt = now
+
+	s.ringTones.insert(ringTone)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) GetRingTone(id uint) (*models.RingTone, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ringTone, ok := s.ringTones.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &ringTone, nil
+}
+
+func (s *MemoryStore) ListRingTones() ([]models.RingTone, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ringTones.list(), nil
+}
+
+func (s *MemoryStore) UpdateRingTone(ringTone *models.RingTone) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	ringTone.UpdatedAt = time.Now()
+	if !s.ringTones.update(ringTone) {
+		return ErrNotFound
+	}
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) DeleteRingTone(id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	s.ringTones.delete(id)
+	return s.changed(prev)
+}
+
+// ===== EPISODE =====
+
+// deleteEpisodes menghapus semua episode milik animeID (lock harus sudah dipegang)
+func (s *MemoryStore) deleteEpisodes(animeID uint) {
+	for _, episode := range s.episodes.list() {
+		if episode.AnimeId == animeID {
+			s.episodes.delete(episode.Id)
+		}
+	}
+}
+
+func (s *MemoryStore) ReplaceEpisodes(animeID uint, episodes []models.Episode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	s.deleteEpisodes(animeID)
+
+	now := time.Now()
+	for i := range episodes {
+		episodes[i].Id = 0
+		episodes[i].AnimeId = animeID
+		if episodes[i].CreatedAt.IsZero() {
+			episodes[i].CreatedAt = now
+		}
+		episodes[i].UpdatedAt = now
+		s.episodes.insert(&episodes[i])
+	}
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) ListEpisodes(animeID uint) ([]models.Episode, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var episodes []models.Episode
+	for _, episode := range s.episodes.list() {
+		if episode.AnimeId == animeID {
+			episodes = append(episodes, episode)
+		}
+	}
+	sort.SliceStable(episodes, func(i, j int) bool { return episodes[i].Number < episodes[j].Number })
+	return episodes, nil
+}
+
+func (s *MemoryStore) UpdateEpisode(episode *models.Episode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	episode.UpdatedAt = time.Now()
+	if !s.episodes.update(episode) {
+		return ErrNotFound
+	}
+	return s.changed(prev)
+}
+
+// ===== HISTORY =====
+
+func (s *MemoryStore) CreateReminderEvent(event *models.ReminderEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	row := *event
+	row.Deliveries = nil
+	s.events.insert(&row)
+	event.Id = row.Id
+
+	for i := range event.Deliveries {
+		event.Deliveries[i].Id = uint(i + 1)
+		event.Deliveries[i].EventId = event.Id
+	}
+	// Simpan salinan deliveries supaya tidak berbagi slice dengan caller
+	row.Deliveries = append([]models.ReminderDelivery(nil), event.Deliveries...)
+	s.events.update(&row)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) GetReminderEvent(id uint) (*models.ReminderEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event, ok := s.events.get(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	event.Deliveries = append([]models.ReminderDelivery(nil), event.Deliveries...)
+	return &event, nil
+}
+
+func (s *MemoryStore) ListReminderEvents(filter models.ReminderEventFilter) ([]models.ReminderEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events []models.ReminderEvent
+	for _, event := range s.events.list() {
+		if filter.Match(event) {
+			event.Deliveries = append([]models.ReminderDelivery(nil), event.Deliveries...)
+			events = append(events, event)
+		}
+	}
+
+	// Terbaru lebih dulu
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].FiredAt.Equal(events[j].FiredAt) {
+			return events[i].Id > events[j].Id
+		}
+		return events[i].FiredAt.After(events[j].FiredAt)
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+func (s *MemoryStore) AddReminderDeliveries(eventID uint, deliveries []models.ReminderDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	event, ok := s.events.get(eventID)
+	if !ok {
+		return ErrNotFound
+	}
+	for i := range deliveries {
+		deliveries[i].Id = uint(len(event.Deliveries) + 1)
+		deliveries[i].EventId = eventID
+		event.Deliveries = append(event.Deliveries, deliveries[i])
+	}
+	s.events.update(&event)
+	return s.changed(prev)
+}
+
+// ===== SNOOZE =====
+
+func (s *MemoryStore) CreateSnooze(snooze *models.Snooze) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	if snooze.CreatedAt.IsZero() {
+		snooze.CreatedAt = time.Now()
+	}
+	s.snoozes.insert(snooze)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) ListSnoozes() ([]models.Snooze, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snoozes := s.snoozes.list()
+	sort.SliceStable(snoozes, func(i, j int) bool { return snoozes[i].Until.Before(snoozes[j].Until) })
+	return snoozes, nil
+}
+
+func (s *MemoryStore) DeleteSnooze(id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	s.snoozes.delete(id)
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) DeleteEventSnoozes(eventID uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	for _, snooze := range s.snoozes.list() {
+		if snooze.EventId == eventID {
+			s.snoozes.delete(snooze.Id)
+		}
+	}
+	return s.changed(prev)
+}
+
+// ===== AIRING STATE =====
+
+func (s *MemoryStore) GetAiringState(animeID, slotID uint, airDate string, offsetMinutes int) (*models.AiringState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, state := range s.states.list() {
+		if state.AnimeId == animeID && state.SlotId == slotID && state.AirDate == airDate && state.OffsetMinutes == offsetMinutes {
+			return &state, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) SaveAiringState(state *models.AiringState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	state.UpdatedAt = time.Now()
+	if state.Id == 0 || !s.states.update(state) {
+		s.states.insert(state)
+	}
+	return s.changed(prev)
+}
+
+func (s *MemoryStore) ListAiringStates(since string) ([]models.AiringState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var states []models.AiringState
+	for _, state := range s.states.list() {
+		if state.AirDate >= since {
+			states = append(states, state)
+		}
+	}
+	sort.SliceStable(states, func(i, j int) bool { return states[i].AirDate < states[j].AirDate })
+	return states, nil
+}
+
+func (s *MemoryStore) DeleteAiringStatesBefore(before string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.snapshot()
+
+	for _, state := range s.states.list() {
+		if state.AirDate < before {
+			s.states.delete(state.Id)
+		}
+	}
+	return s.changed(prev)
+}
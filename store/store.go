@@ -0,0 +1,83 @@
+package store
+
+import (
+	"anime-reminder/models"
+	"errors"
+)
+
+// ErrNotFound dikembalikan semua implementasi store jika data tidak ditemukan
+var ErrNotFound = errors.New("record not found")
+
+// AnimeStore menyimpan data anime
+type AnimeStore interface {
+	CreateAnime(anime *models.Anime) error
+	GetAnime(id uint) (*models.Anime, error)
+	GetAnimeByTitle(title string) (*models.Anime, error)
+	ListAnimes() ([]models.Anime, error)
+	UpdateAnime(anime *models.Anime) error
+	DeleteAnime(id uint) error
+}
+
+// RingToneStore menyimpan data ringtone
+type RingToneStore interface {
+	CreateRingTone(ringTone *models.RingTone) error
+	GetRingTone(id uint) (*models.RingTone, error)
+	ListRingTones() ([]models.RingTone, error)
+	UpdateRingTone(ringTone *models.RingTone) error
+	DeleteRingTone(id uint) error
+}
+
+// EpisodeStore menyimpan episode per anime.
+// Episode ikut terhapus ketika anime-nya dihapus lewat AnimeStore.DeleteAnime.
+type EpisodeStore interface {
+	// ReplaceEpisodes mengganti semua episode milik animeID dengan episodes
+	ReplaceEpisodes(animeID uint, episodes []models.Episode) error
+	ListEpisodes(animeID uint) ([]models.Episode, error)
+	UpdateEpisode(episode *models.Episode) error
+}
+
+// HistoryStore menyimpan history reminder yang sudah di-fire
+type HistoryStore interface {
+	CreateReminderEvent(event *models.ReminderEvent) error
+	GetReminderEvent(id uint) (*models.ReminderEvent, error)
+	// ListReminderEvents mengembalikan event terbaru lebih dulu
+	ListReminderEvents(filter models.ReminderEventFilter) ([]models.ReminderEvent, error)
+	// AddReminderDeliveries menambahkan hasil pengiriman yang selesai belakangan
+	// (channel remote) ke event yang sudah disimpan
+	AddReminderDeliveries(eventID uint, deliveries []models.ReminderDelivery) error
+}
+
+// SnoozeStore menyimpan reminder yang sedang di-snooze.
+// Snooze ikut terhapus ketika anime-nya dihapus lewat AnimeStore.DeleteAnime.
+type SnoozeStore interface {
+	CreateSnooze(snooze *models.Snooze) error
+	// ListSnoozes mengembalikan semua snooze, paling cepat lebih dulu
+	ListSnoozes() ([]models.Snooze, error)
+	DeleteSnooze(id uint) error
+	// DeleteEventSnoozes menghapus semua snooze milik satu ReminderEvent
+	DeleteEventSnoozes(eventID uint) error
+}
+
+// AiringStateStore menyimpan status de-duplikasi reminder per tayangan.
+// Status ikut terhapus ketika anime-nya dihapus lewat AnimeStore.DeleteAnime.
+type AiringStateStore interface {
+	// GetAiringState mencari status berdasarkan kunci unik, ErrNotFound jika belum ada
+	GetAiringState(animeID, slotID uint, airDate string, offsetMinutes int) (*models.AiringState, error)
+	// SaveAiringState membuat atau meng-update status
+	SaveAiringState(state *models.AiringState) error
+	// ListAiringStates mengembalikan status dengan AirDate >= since (YYYY-MM-DD)
+	ListAiringStates(since string) ([]models.AiringState, error)
+	// DeleteAiringStatesBefore menghapus status dengan AirDate < before (YYYY-MM-DD)
+	DeleteAiringStatesBefore(before string) error
+}
+
+// Store adalah gabungan semua store, diimplementasikan oleh GormStore,
+// MemoryStore dan JSONFileStore
+type Store interface {
+	AnimeStore
+	RingToneStore
+	EpisodeStore
+	HistoryStore
+	SnoozeStore
+	AiringStateStore
+}
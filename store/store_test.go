@@ -0,0 +1,517 @@
+package store
+
+import (
+	"anime-reminder/models"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// backend membuat store kosong untuk conformance test. reopen true jika
+// memanggil open lagi di folder yang sama membaca data yang sudah disimpan.
+type backend struct {
+	name   string
+	open   func(t *testing.T, dir string) Store
+	reopen bool
+}
+
+var backends = []backend{
+	{name: "gorm", open: openGormStore, reopen: true},
+	{name: "memory", open: func(t *testing.T, dir string) Store { return NewMemoryStore() }},
+	{name: "json", open: openJSONFileStore, reopen: true},
+}
+
+func openGormStore(t *testing.T, dir string) Store {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	err = db.AutoMigrate(&models.Anime{}, &models.ScheduleSlot{}, &models.RingTone{}, &models.Episode{},
+		&models.ReminderEvent{}, &models.ReminderDelivery{}, &models.Snooze{}, &models.AiringState{})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewGormStore(db)
+}
+
+func openJSONFileStore(t *testing.T, dir string) Store {
+	t.Helper()
+	s, err := NewJSONFileStore(filepath.Join(dir, "library.json"))
+	if err != nil {
+		t.Fatalf("open json store: %v", err)
+	}
+	return s
+}
+
+// forEachBackend menjalankan test yang sama untuk setiap implementasi Store
+func forEachBackend(t *testing.T, test func(t *testing.T, b backend, dir string, s Store)) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			dir := t.TempDir()
+			test(t, b, dir, b.open(t, dir))
+		})
+	}
+}
+
+func clockAt(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func newTestAnime(title string) *models.Anime {
+	return &models.Anime{
+		Title:    title,
+		Timezone: "Asia/Tokyo",
+		Slots: []models.ScheduleSlot{
+			{Label: "TV", Day: models.Saturday, Time: clockAt(1, 30), LateNight: true, Enabled: true},
+			{Label: "Crunchyroll", Day: models.Saturday, Time: clockAt(14, 0), Enabled: true},
+		},
+	}
+}
+
+func mustCreateAnime(t *testing.T, s Store, title string) *models.Anime {
+	t.Helper()
+	anime := newTestAnime(title)
+	if err := s.CreateAnime(anime); err != nil {
+		t.Fatalf("CreateAnime: %v", err)
+	}
+	if anime.Id == 0 {
+		t.Fatal("CreateAnime did not assign an id")
+	}
+	return anime
+}
+
+func TestStoreAnimeSlots(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		anime := mustCreateAnime(t, s, "Frieren")
+
+		got, err := s.GetAnime(anime.Id)
+		if err != nil {
+			t.Fatalf("GetAnime: %v", err)
+		}
+		if len(got.Slots) != 2 {
+			t.Fatalf("got %d slots, want 2", len(got.Slots))
+		}
+		first := got.Slots[0]
+		if first.Id == 0 || first.AnimeId != anime.Id {
+			t.Fatalf("slot not linked: id %d anime %d", first.Id, first.AnimeId)
+		}
+		if first.Day != models.Saturday || first.Time.Format("15:04") != "01:30" || !first.LateNight {
+			t.Fatalf("slot = %s, want late-night Saturday 01:30", first.Summary())
+		}
+
+		// Ubah slot pertama, hapus slot kedua, tambah slot baru
+		got.Slots[0].Label = "BS11"
+		got.Slots = []models.ScheduleSlot{got.Slots[0], {Label: "Netflix", Day: models.Sunday, Time: clockAt(9, 0), Enabled: true}}
+		if err := s.UpdateAnime(got); err != nil {
+			t.Fatalf("UpdateAnime: %v", err)
+		}
+
+		updated, err := s.GetAnimeByTitle("Frieren")
+		if err != nil {
+			t.Fatalf("GetAnimeByTitle: %v", err)
+		}
+		if len(updated.Slots) != 2 {
+			t.Fatalf("got %d slots after update, want 2", len(updated.Slots))
+		}
+		if updated.Slots[0].Id != first.Id || updated.Slots[0].Label != "BS11" {
+			t.Errorf("first slot = %d %q, want %d \"BS11\"", updated.Slots[0].Id, updated.Slots[0].Label, first.Id)
+		}
+		if updated.Slots[1].Id == 0 || updated.Slots[1].Label != "Netflix" {
+			t.Errorf("new slot = %d %q, want a new \"Netflix\" slot", updated.Slots[1].Id, updated.Slots[1].Label)
+		}
+
+		// Data yang dikembalikan tidak boleh berbagi slice dengan isi store
+		updated.Slots[0].Label = "changed"
+		again, _ := s.GetAnime(anime.Id)
+		if again.Slots[0].Label != "BS11" {
+			t.Errorf("store shares slot slice with caller")
+		}
+
+		if _, err := s.GetAnimeByTitle("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetAnimeByTitle(missing) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestStoreSnoozes(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		now := time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC)
+		late := &models.Snooze{EventId: 1, AnimeId: 1, SlotId: 1, Until: now.Add(30 * time.Minute)}
+		early := &models.Snooze{EventId: 2, AnimeId: 1, SlotId: 2, Until: now.Add(5 * time.Minute)}
+		other := &models.Snooze{EventId: 2, AnimeId: 1, SlotId: 2, Until: now.Add(10 * time.Minute)}
+		for _, snooze := range []*models.Snooze{late, early, other} {
+			if err := s.CreateSnooze(snooze); err != nil {
+				t.Fatalf("CreateSnooze: %v", err)
+			}
+		}
+
+		snoozes, err := s.ListSnoozes()
+		if err != nil {
+			t.Fatalf("ListSnoozes: %v", err)
+		}
+		if len(snoozes) != 3 || snoozes[0].Id != early.Id || snoozes[2].Id != late.Id {
+			t.Fatalf("ListSnoozes not ordered by Until: %+v", snoozes)
+		}
+
+		if err := s.DeleteEventSnoozes(2); err != nil {
+			t.Fatalf("DeleteEventSnoozes: %v", err)
+		}
+		if err := s.DeleteSnooze(late.Id); err != nil {
+			t.Fatalf("DeleteSnooze: %v", err)
+		}
+		if snoozes, _ := s.ListSnoozes(); len(snoozes) != 0 {
+			t.Errorf("got %d snoozes after delete, want 0", len(snoozes))
+		}
+	})
+}
+
+func TestStoreAiringStates(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		if _, err := s.GetAiringState(1, 1, "2026-10-17", 0); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetAiringState on empty store error = %v, want ErrNotFound", err)
+		}
+
+		firedAt := time.Date(2026, 10, 17, 16, 30, 0, 0, time.UTC)
+		state := &models.AiringState{AnimeId: 1, SlotId: 1, AirDate: "2026-10-17", OffsetMinutes: 0, FiredAt: &firedAt}
+		if err := s.SaveAiringState(state); err != nil {
+			t.Fatalf("SaveAiringState: %v", err)
+		}
+		advance := &models.AiringState{AnimeId: 1, SlotId: 1, AirDate: "2026-10-17", OffsetMinutes: 15}
+		if err := s.SaveAiringState(advance); err != nil {
+			t.Fatalf("SaveAiringState: %v", err)
+		}
+		old := &models.AiringState{AnimeId: 1, SlotId: 1, AirDate: "2026-10-10", FiredAt: &firedAt}
+		if err := s.SaveAiringState(old); err != nil {
+			t.Fatalf("SaveAiringState: %v", err)
+		}
+
+		got, err := s.GetAiringState(1, 1, "2026-10-17", 15)
+		if err != nil {
+			t.Fatalf("GetAiringState: %v", err)
+		}
+		if got.Handled() {
+			t.Fatalf("advance state should not be handled yet")
+		}
+
+		// Update lewat Save tidak boleh membuat row baru
+		acknowledged := firedAt.Add(time.Minute)
+		got.AcknowledgedAt = &acknowledged
+		if err := s.SaveAiringState(got); err != nil {
+			t.Fatalf("SaveAiringState update: %v", err)
+		}
+		got, _ = s.GetAiringState(1, 1, "2026-10-17", 15)
+		if got == nil || !got.Handled() {
+			t.Fatalf("acknowledged state not saved")
+		}
+
+		states, err := s.ListAiringStates("2026-10-15")
+		if err != nil {
+			t.Fatalf("ListAiringStates: %v", err)
+		}
+		if len(states) != 2 {
+			t.Fatalf("ListAiringStates returned %d states, want 2", len(states))
+		}
+		for _, state := range states {
+			if state.AirDate < "2026-10-15" {
+				t.Errorf("ListAiringStates returned state from %s", state.AirDate)
+			}
+		}
+	})
+}
+
+func TestStoreDeleteAnimeCascades(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		deleted := mustCreateAnime(t, s, "Frieren")
+		kept := mustCreateAnime(t, s, "Dandadan")
+
+		now := time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC)
+		for _, anime := range []*models.Anime{deleted, kept} {
+			episodes := []models.Episode{{Number: 1, AirAt: now}, {Number: 2, AirAt: now.AddDate(0, 0, 7)}}
+			if err := s.ReplaceEpisodes(anime.Id, episodes); err != nil {
+				t.Fatalf("ReplaceEpisodes: %v", err)
+			}
+			if err := s.CreateSnooze(&models.Snooze{EventId: anime.Id, AnimeId: anime.Id, SlotId: anime.Slots[0].Id, Until: now}); err != nil {
+				t.Fatalf("CreateSnooze: %v", err)
+			}
+			if err := s.SaveAiringState(&models.AiringState{AnimeId: anime.Id, SlotId: anime.Slots[0].Id, AirDate: "2026-10-17"}); err != nil {
+				t.Fatalf("SaveAiringState: %v", err)
+			}
+		}
+
+		if err := s.DeleteAnime(deleted.Id); err != nil {
+			t.Fatalf("DeleteAnime: %v", err)
+		}
+
+		if _, err := s.GetAnime(deleted.Id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetAnime after delete error = %v, want ErrNotFound", err)
+		}
+		if episodes, _ := s.ListEpisodes(deleted.Id); len(episodes) != 0 {
+			t.Errorf("%d episodes left for deleted anime", len(episodes))
+		}
+		if episodes, _ := s.ListEpisodes(kept.Id); len(episodes) != 2 {
+			t.Errorf("other anime has %d episodes, want 2", len(episodes))
+		}
+
+		snoozes, _ := s.ListSnoozes()
+		if len(snoozes) != 1 || snoozes[0].AnimeId != kept.Id {
+			t.Errorf("snoozes after delete = %+v, want only anime %d", snoozes, kept.Id)
+		}
+		states, _ := s.ListAiringStates("")
+		if len(states) != 1 || states[0].AnimeId != kept.Id {
+			t.Errorf("airing states after delete = %+v, want only anime %d", states, kept.Id)
+		}
+
+		animes, _ := s.ListAnimes()
+		if len(animes) != 1 || animes[0].Id != kept.Id || len(animes[0].Slots) != 2 {
+			t.Errorf("ListAnimes after delete = %+v, want only %q with its slots", animes, kept.Title)
+		}
+	})
+}
+
+func TestStoreReload(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		if !b.reopen {
+			t.Skip("store is not persistent")
+		}
+
+		anime := mustCreateAnime(t, s, "Frieren")
+		if err := s.CreateRingTone(&models.RingTone{Name: "Opening", SongPath: "uploads/op.mp3"}); err != nil {
+			t.Fatalf("CreateRingTone: %v", err)
+		}
+		until := time.Date(2026, 10, 17, 21, 30, 0, 0, time.UTC)
+		if err := s.CreateSnooze(&models.Snooze{EventId: 7, AnimeId: anime.Id, SlotId: anime.Slots[0].Id, Until: until}); err != nil {
+			t.Fatalf("CreateSnooze: %v", err)
+		}
+		if err := s.SaveAiringState(&models.AiringState{AnimeId: anime.Id, SlotId: anime.Slots[0].Id, AirDate: "2026-10-17", OffsetMinutes: 15}); err != nil {
+			t.Fatalf("SaveAiringState: %v", err)
+		}
+
+		reopened := b.open(t, dir)
+
+		got, err := reopened.GetAnime(anime.Id)
+		if err != nil {
+			t.Fatalf("GetAnime after reload: %v", err)
+		}
+		if got.Timezone != "Asia/Tokyo" || len(got.Slots) != 2 || got.Slots[0].Id != anime.Slots[0].Id {
+			t.Fatalf("anime after reload = %+v", got)
+		}
+		if ringTones, _ := reopened.ListRingTones(); len(ringTones) != 1 || ringTones[0].Name != "Opening" {
+			t.Errorf("ring tones after reload = %+v", ringTones)
+		}
+		snoozes, _ := reopened.ListSnoozes()
+		if len(snoozes) != 1 || !snoozes[0].Until.Equal(until) {
+			t.Errorf("snoozes after reload = %+v", snoozes)
+		}
+		if _, err := reopened.GetAiringState(anime.Id, anime.Slots[0].Id, "2026-10-17", 15); err != nil {
+			t.Errorf("airing state after reload: %v", err)
+		}
+
+		// Id baru setelah reload tidak boleh bentrok dengan data lama
+		second := mustCreateAnime(t, reopened, "Dandadan")
+		if second.Id == anime.Id {
+			t.Errorf("new anime reused id %d", anime.Id)
+		}
+		for _, slot := range second.Slots {
+			for _, old := range anime.Slots {
+				if slot.Id == old.Id {
+					t.Errorf("new slot reused id %d", slot.Id)
+				}
+			}
+		}
+	})
+}
+
+func TestStorePruneAiringStates(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		for _, airDate := range []string{"2026-10-01", "2026-10-09", "2026-10-10", "2026-10-17"} {
+			if err := s.SaveAiringState(&models.AiringState{AnimeId: 1, SlotId: 1, AirDate: airDate}); err != nil {
+				t.Fatalf("SaveAiringState: %v", err)
+			}
+		}
+
+		if err := s.DeleteAiringStatesBefore("2026-10-10"); err != nil {
+			t.Fatalf("DeleteAiringStatesBefore: %v", err)
+		}
+
+		states, _ := s.ListAiringStates("")
+		if len(states) != 2 || states[0].AirDate != "2026-10-10" || states[1].AirDate != "2026-10-17" {
+			t.Errorf("states after prune = %+v, want 2026-10-10 and 2026-10-17", states)
+		}
+	})
+}
+
+func TestStoreAddReminderDeliveries(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b backend, dir string, s Store) {
+		event := &models.ReminderEvent{
+			AnimeTitle: "Frieren",
+			FiredAt:    time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC),
+			Deliveries: []models.ReminderDelivery{{Channel: "dbus", Success: true}},
+		}
+		if err := s.CreateReminderEvent(event); err != nil {
+			t.Fatalf("CreateReminderEvent: %v", err)
+		}
+
+		// Channel remote selesai belakangan
+		remote := []models.ReminderDelivery{
+			{Channel: "webhook:team", Success: false, Error: "server returned 503"},
+			{Channel: "telegram", Success: true},
+		}
+		if err := s.AddReminderDeliveries(event.Id, remote); err != nil {
+			t.Fatalf("AddReminderDeliveries: %v", err)
+		}
+
+		check := func(t *testing.T, s Store) {
+			got, err := s.GetReminderEvent(event.Id)
+			if err != nil {
+				t.Fatalf("GetReminderEvent: %v", err)
+			}
+			channels := make([]string, 0, len(got.Deliveries))
+			for _, delivery := range got.Deliveries {
+				channels = append(channels, delivery.Channel)
+			}
+			if strings.Join(channels, ",") != "dbus,webhook:team,telegram" {
+				t.Errorf("delivery channels = %v", channels)
+			}
+		}
+		check(t, s)
+		if b.reopen {
+			check(t, b.open(t, dir))
+		}
+
+		if err := s.AddReminderDeliveries(event.Id+100, remote); err == nil {
+			t.Error("AddReminderDeliveries accepted an unknown event")
+		}
+	})
+}
+
+func TestMemoryStoreRollsBackOnPersistError(t *testing.T) {
+	s := NewMemoryStore()
+	anime := mustCreateAnime(t, s, "Frieren")
+
+	s.persist = func() error { return errors.New("disk full") }
+	if err := s.CreateAnime(newTestAnime("Dandadan")); err == nil {
+		t.Fatal("CreateAnime ignored the persist error")
+	}
+	if err := s.DeleteAnime(anime.Id); err == nil {
+		t.Fatal("DeleteAnime ignored the persist error")
+	}
+	if err := s.SaveAiringState(&models.AiringState{AnimeId: anime.Id, SlotId: anime.Slots[0].Id, AirDate: "2026-10-17"}); err == nil {
+		t.Fatal("SaveAiringState ignored the persist error")
+	}
+
+	// Memory harus sama dengan data terakhir yang berhasil disimpan
+	animes, _ := s.ListAnimes()
+	if len(animes) != 1 || animes[0].Title != "Frieren" {
+		t.Errorf("animes after failed writes = %+v, want only Frieren", animes)
+	}
+	if states, _ := s.ListAiringStates(""); len(states) != 0 {
+		t.Errorf("airing states after failed write = %+v", states)
+	}
+
+	// Id dan slot id yang gagal disimpan dipakai lagi
+	s.persist = nil
+	second := mustCreateAnime(t, s, "Dandadan")
+	if second.Id != anime.Id+1 || second.Slots[0].Id != anime.Slots[1].Id+1 {
+		t.Errorf("anime id %d slot id %d after rollback, want %d and %d",
+			second.Id, second.Slots[0].Id, anime.Id+1, anime.Slots[1].Id+1)
+	}
+}
+
+func TestJSONFileStoreKeepsRuntimeStateSeparate(t *testing.T) {
+	dir := t.TempDir()
+	s := openJSONFileStore(t, dir).(*JSONFileStore)
+	if want := filepath.Join(dir, "library.state.json"); s.StatePath() != want {
+		t.Fatalf("StatePath() = %q, want %q", s.StatePath(), want)
+	}
+
+	anime := mustCreateAnime(t, s, "Frieren")
+	library, err := os.ReadFile(s.Path())
+	if err != nil {
+		t.Fatalf("read library: %v", err)
+	}
+
+	// Data runtime hanya mengubah file state
+	if err := s.CreateSnooze(&models.Snooze{EventId: 1, AnimeId: anime.Id, Until: time.Date(2026, 10, 17, 21, 30, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("CreateSnooze: %v", err)
+	}
+	if err := s.SaveAiringState(&models.AiringState{AnimeId: anime.Id, SlotId: anime.Slots[0].Id, AirDate: "2026-10-17"}); err != nil {
+		t.Fatalf("SaveAiringState: %v", err)
+	}
+	after, _ := os.ReadFile(s.Path())
+	if string(after) != string(library) {
+		t.Errorf("library changed after runtime writes:\n%s", after)
+	}
+	for _, key := range []string{"reminder_events", "snoozes", "airing_states"} {
+		if strings.Contains(string(after), key) {
+			t.Errorf("library contains %q", key)
+		}
+	}
+	state, err := os.ReadFile(s.StatePath())
+	if err != nil || !strings.Contains(string(state), `"airing_states"`) {
+		t.Errorf("state file = %s, %v", state, err)
+	}
+}
+
+func TestJSONFileStoreLoadsLegacyLibrary(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "library.json")
+	legacy := `{
+  "animes": [{"Id": 3, "Title": "Frieren", "Timezone": "Asia/Tokyo"}],
+  "ring_tones": [],
+  "episodes": [],
+  "reminder_events": [{"Id": 5, "AnimeTitle": "Frieren"}],
+  "snoozes": [{"Id": 2, "EventId": 5, "AnimeId": 3}],
+  "airing_states": [{"Id": 9, "AnimeId": 3, "AirDate": "2026-10-17"}]
+}
+`
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("open legacy library: %v", err)
+	}
+	if _, err := s.GetReminderEvent(5); err != nil {
+		t.Errorf("legacy reminder event: %v", err)
+	}
+	if snoozes, _ := s.ListSnoozes(); len(snoozes) != 1 {
+		t.Errorf("legacy snoozes = %+v", snoozes)
+	}
+
+	// Penyimpanan berikutnya memindahkan data runtime ke file state
+	if err := s.DeleteAiringStatesBefore("2026-01-01"); err != nil {
+		t.Fatalf("DeleteAiringStatesBefore: %v", err)
+	}
+	library, _ := os.ReadFile(path)
+	if strings.Contains(string(library), "snoozes") {
+		t.Errorf("library still contains runtime data:\n%s", library)
+	}
+
+	reopened, err := NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if states, _ := reopened.ListAiringStates(""); len(states) != 1 || states[0].Id != 9 {
+		t.Errorf("airing states after migration = %+v", states)
+	}
+	if _, err := reopened.GetAnime(3); err != nil {
+		t.Errorf("anime after migration: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"anime-reminder/utils"
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+)
+
+// coverThumbSize adalah ukuran thumbnail cover di daftar anime dan popup
+const coverThumbSize = 48
+
+// newCoverImage membuat canvas.Image kosong berukuran tetap untuk cover anime
+func newCoverImage(size float32) *canvas.Image {
+	img := &canvas.Image{FillMode: canvas.ImageFillContain}
+	img.SetMinSize(fyne.NewSize(size, size))
+	return img
+}
+
+// setCover memuat thumbnail cover (dari cache utils.CoverIcon) ke img.
+// Anime tanpa cover dibiarkan kosong.
+func setCover(img *canvas.Image, imagePath string) {
+	img.File = ""
+	if imagePath != "" {
+		thumb, err := utils.CoverIcon(imagePath, utils.CoverThumbSize)
+		if err != nil {
+			log.Printf("⚠️ Failed to load cover %s: %v", imagePath, err)
+		} else {
+			img.File = thumb
+		}
+	}
+	img.Refresh()
+}
+
+// withCover menaruh gambar di kiri content jika ada
+func withCover(img *canvas.Image, content fyne.CanvasObject) fyne.CanvasObject {
+	if img.File == "" {
+		return content
+	}
+	return container.NewBorder(nil, nil, container.NewVBox(img), nil, content)
+}
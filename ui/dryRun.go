@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"anime-reminder/scheduler"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showDryRunDialog menampilkan reminder yang akan di-fire di rentang tanggal tertentu,
+// dihitung oleh scheduler.Simulate tanpa mengirim notifikasi
+func (mw *MainWindow) showDryRunDialog() {
+	today := time.Now()
+
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(today.Format("2006-01-02"))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(today.AddDate(0, 0, 7).Format("2006-01-02"))
+
+	var results []scheduler.SimulatedReminder
+	summary := widget.NewLabel("")
+	list := widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(formatSimulatedReminder(results[id]))
+		},
+	)
+
+	run := func() {
+		from, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromEntry.Text), time.Local)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid start date, use YYYY-MM-DD"), mw.window)
+			return
+		}
+		to, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(toEntry.Text), time.Local)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid end date, use YYYY-MM-DD"), mw.window)
+			return
+		}
+		// Mulai dari sekarang jika rentang dimulai hari ini, tanggal akhir ikut dihitung
+		if from.Before(today) {
+			from = today
+		}
+		to = to.AddDate(0, 0, 1)
+
+		results, err = scheduler.Simulate(mw.controllers, from, to)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		summary.SetText(fmt.Sprintf("%d reminder(s) would fire", len(results)))
+		list.Refresh()
+	}
+
+	runBtn := widget.NewButton("Run", run)
+	run()
+
+	controls := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("From", fromEntry),
+			widget.NewFormItem("To", toEntry),
+		),
+		runBtn,
+		summary,
+	)
+
+	d := dialog.NewCustom("Dry Run", "Close", container.NewBorder(controls, nil, nil, nil, list), mw.window)
+	d.Resize(fyne.NewSize(640, 520))
+	d.Show()
+}
+
+// formatSimulatedReminder menghasilkan teks seperti
+// "Sabtu 2026-10-24 23:30  ⏰ 30 min before  Frieren (TV)"
+func formatSimulatedReminder(r scheduler.SimulatedReminder) string {
+	local := r.FireAt.In(time.Local)
+	when := fmt.Sprintf("%s %s", models.WeekdayOf(local), local.Format("2006-01-02 15:04"))
+
+	name := r.AnimeTitle
+	if r.SlotLabel != "" {
+		name += fmt.Sprintf(" (%s)", r.SlotLabel)
+	}
+
+	switch r.Kind {
+	case "advance":
+		return fmt.Sprintf("%s  ⏰ %d min before  %s", when, r.OffsetMinutes, name)
+	case "snooze":
+		return fmt.Sprintf("%s  💤 snoozed  %s", when, name)
+	case "digest":
+		return fmt.Sprintf("%s  📅 %s", when, name)
+	default:
+		return fmt.Sprintf("%s  🎬 airing  %s", when, name)
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showEpisodesDialog menampilkan daftar episode, status watched, dan form generate jadwal
+func (mw *MainWindow) showEpisodesDialog(anime models.Anime, onChanged func()) {
+	var episodes []models.Episode
+	reload := func() {
+		var err error
+		episodes, err = mw.episodeController.GetEpisodes(anime.Id)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+	}
+	reload()
+
+	summaryLabel := widget.NewLabel("")
+	updateSummary := func() {
+		backlog, _ := mw.episodeController.Backlog(anime.Id)
+		summaryLabel.SetText(fmt.Sprintf("%d episodes, %d unwatched", len(episodes), backlog))
+	}
+	updateSummary()
+
+	var episodeList *widget.List
+	episodeList = widget.NewList(
+		func() int {
+			return len(episodes)
+		},
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				widget.NewCheck("", nil),
+				widget.NewLabel("Template"),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= len(episodes) {
+				return
+			}
+			episode := episodes[id]
+
+			row := item.(*fyne.Container)
+			watchedCheck := row.Objects[0].(*widget.Check)
+			label := row.Objects[1].(*widget.Label)
+
+			status := "upcoming"
+			if episode.HasAired(time.Now()) {
+				status = "aired"
+			}
+			text := fmt.Sprintf("Ep %d - %s (%s)", episode.Number, episode.AirAt.Format("2006-01-02 15:04"), status)
+			if episode.Title != "" {
+				text = fmt.Sprintf("Ep %d: %s - %s (%s)", episode.Number, episode.Title, episode.AirAt.Format("2006-01-02 15:04"), status)
+			}
+			label.SetText(text)
+
+			// Set OnChanged setelah SetChecked supaya tidak memicu update
+			watchedCheck.OnChanged = nil
+			watchedCheck.SetChecked(episode.Watched)
+			watchedCheck.OnChanged = func(checked bool) {
+				if err := mw.episodeController.SetWatched(&episodes[id], checked); err != nil {
+					dialog.ShowError(err, mw.window)
+					return
+				}
+				updateSummary()
+				if onChanged != nil {
+					onChanged()
+				}
+			}
+		},
+	)
+
+	startEntry := widget.NewEntry()
+	startEntry.SetPlaceHolder("Start date (YYYY-MM-DD)")
+	switch {
+	case len(episodes) > 0:
+		startEntry.SetText(episodes[0].AirAt.Format("2006-01-02"))
+	case anime.PremiereDate != nil:
+		startEntry.SetText(formatOptionalDate(anime.PremiereDate))
+	default:
+		startEntry.SetText(time.Now().Format("2006-01-02"))
+	}
+
+	countEntry := widget.NewEntry()
+	countEntry.SetPlaceHolder("Episode count")
+	switch {
+	case len(episodes) > 0:
+		countEntry.SetText(strconv.Itoa(len(episodes)))
+	case anime.EpisodeCount > 0:
+		countEntry.SetText(strconv.Itoa(anime.EpisodeCount))
+	}
+
+	generateBtn := widget.NewButton("Generate Episodes", func() {
+		startDate, err := time.ParseInLocation("2006-01-02", startEntry.Text, time.Local)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid start date, use YYYY-MM-DD"), mw.window)
+			return
+		}
+		count, err := strconv.Atoi(countEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid episode count"), mw.window)
+			return
+		}
+
+		if _, err := mw.episodeController.GenerateEpisodes(&anime, startDate, count); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		reload()
+		updateSummary()
+		episodeList.Refresh()
+		if onChanged != nil {
+			onChanged()
+		}
+	})
+
+	generateForm := container.NewVBox(
+		widget.NewLabel("Generate schedule from the weekly slot:"),
+		container.NewGridWithColumns(2, startEntry, countEntry),
+		generateBtn,
+		summaryLabel,
+	)
+
+	content := container.NewBorder(generateForm, nil, nil, nil, episodeList)
+
+	d := dialog.NewCustom(fmt.Sprintf("Episodes - %s", anime.Title), "Close", content, mw.window)
+	d.Resize(fyne.NewSize(500, 500))
+	d.Show()
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"anime-reminder/utils"
 	"fmt"
 	"io"
 	"os"
@@ -8,6 +9,8 @@ import (
 	"time"
 )
 
+// Direktori upload relatif terhadap data directory (lihat utils.DataDir).
+// Path yang disimpan di database juga relatif, supaya data directory bisa dipindah.
 const (
 	ImageUploadDir = "uploads/images"
 	AudioUploadDir = "uploads/audio"
@@ -17,7 +20,7 @@ const (
 func InitUploadDirectories() error {
 	dirs := []string{ImageUploadDir, AudioUploadDir}
 	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := os.MkdirAll(utils.ResolveDataPath(dir), 0755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %v", dir, err)
 		}
 	}
@@ -65,7 +68,7 @@ func UploadImage(sourcePath string) (string, error) {
 	destPath := filepath.Join(ImageUploadDir, filename)
 
 	// Copy file
-	if err := CopyFile(sourcePath, destPath); err != nil {
+	if err := CopyFile(sourcePath, utils.ResolveDataPath(destPath)); err != nil {
 		return "", fmt.Errorf("failed to upload image: %v", err)
 	}
 
@@ -85,7 +88,7 @@ func UploadAudio(sourcePath string) (string, error) {
 	destPath := filepath.Join(AudioUploadDir, filename)
 
 	// Copy file
-	if err := CopyFile(sourcePath, destPath); err != nil {
+	if err := CopyFile(sourcePath, utils.ResolveDataPath(destPath)); err != nil {
 		return "", fmt.Errorf("failed to upload audio: %v", err)
 	}
 
@@ -98,7 +101,7 @@ func DeleteFile(path string) error {
 		return nil
 	}
 
-	if err := os.Remove(path); err != nil {
+	if err := os.Remove(utils.ResolveDataPath(path)); err != nil {
 		if os.IsNotExist(err) {
 			return nil // File sudah tidak ada
 		}
@@ -110,6 +113,6 @@ func DeleteFile(path string) error {
 
 // FileExists mengecek apakah file ada
 func FileExists(path string) bool {
-	_, err := os.Stat(path)
+	_, err := os.Stat(utils.ResolveDataPath(path))
 	return err == nil
 }
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"anime-reminder/scheduler"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const allAnimeOption = "All anime"
+
+func (mw *MainWindow) createHistoryTab() fyne.CanvasObject {
+	var events []models.ReminderEvent
+	filter := models.ReminderEventFilter{Limit: 500}
+
+	// Filter anime
+	animeMap := make(map[string]uint)
+	animeSelect := widget.NewSelect(nil, nil)
+	loadAnimeOptions := func() {
+		options := []string{allAnimeOption}
+		animes, _ := mw.animeController.GetAllAnimes()
+		for _, anime := range animes {
+			options = append(options, anime.Title)
+			animeMap[anime.Title] = anime.Id
+		}
+		animeSelect.Options = options
+		animeSelect.Refresh()
+	}
+	loadAnimeOptions()
+	animeSelect.SetSelected(allAnimeOption)
+
+	// Filter tanggal (inklusif)
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder("From (YYYY-MM-DD)")
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder("To (YYYY-MM-DD)")
+
+	var historyList *widget.List
+	load := func() {
+		var err error
+		events, err = mw.historyController.GetEvents(filter)
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+		}
+		historyList.Refresh()
+	}
+
+	historyList = widget.NewList(
+		func() int {
+			return len(events)
+		},
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil,
+				widget.NewButton("Re-send", func() {}),
+				widget.NewLabel("Template"),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= len(events) {
+				return
+			}
+			event := events[id]
+
+			row := item.(*fyne.Container)
+			label := row.Objects[0].(*widget.Label)
+			label.SetText(formatHistoryEvent(event))
+
+			resendBtn := row.Objects[1].(*widget.Button)
+			resendBtn.OnTapped = func() {
+				if _, err := scheduler.ResendReminder(mw.controllers, event.Id); err != nil {
+					dialog.ShowError(err, mw.window)
+				}
+				load()
+			}
+		},
+	)
+
+	applyBtn := widget.NewButton("Apply Filter", func() {
+		from, err := parseOptionalDate(fromEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid from date, use YYYY-MM-DD"), mw.window)
+			return
+		}
+		to, err := parseOptionalDate(toEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("invalid to date, use YYYY-MM-DD"), mw.window)
+			return
+		}
+
+		filter = models.ReminderEventFilter{Limit: 500}
+		filter.AnimeId = animeMap[animeSelect.Selected]
+		if from != nil {
+			filter.From = *from
+		}
+		if to != nil {
+			// Tanggal "to" inklusif sampai akhir hari
+			filter.To = to.AddDate(0, 0, 1)
+		}
+		load()
+	})
+
+	refreshBtn := widget.NewButton("Refresh", func() {
+		loadAnimeOptions()
+		load()
+	})
+
+	load()
+
+	filterBar := container.NewGridWithColumns(5, animeSelect, fromEntry, toEntry, applyBtn, refreshBtn)
+	return container.NewBorder(filterBar, nil, nil, nil, historyList)
+}
+
+// formatHistoryEvent menampilkan satu baris history: waktu, anime, hasil per channel, audio
+func formatHistoryEvent(event models.ReminderEvent) string {
+	name := event.AnimeTitle
+	if event.EpisodeNumber > 0 {
+		name = fmt.Sprintf("%s (Ep %d)", event.AnimeTitle, event.EpisodeNumber)
+	}
+	if event.OffsetMinutes > 0 {
+		name += fmt.Sprintf(" [%d min before]", event.OffsetMinutes)
+	}
+	if event.ResentFromId != 0 {
+		name += " [re-sent]"
+	}
+
+	channels := make([]string, 0, len(event.Deliveries))
+	for _, delivery := range event.Deliveries {
+		status := "✓"
+		if !delivery.Success {
+			status = "✗"
+		}
+		channels = append(channels, delivery.Channel+" "+status)
+	}
+
+	audio := "audio -"
+	if event.AudioPlayed {
+		audio = "audio ✓"
+		if event.RingToneName != "" {
+			audio = fmt.Sprintf("audio ✓ (%s)", event.RingToneName)
+		}
+	}
+
+	return fmt.Sprintf("%s  %s  |  %s  |  %s",
+		event.FiredAt.Format("2006-01-02 15:04"),
+		name,
+		strings.Join(channels, ", "),
+		audio)
+}
@@ -5,9 +5,12 @@ import (
 	"anime-reminder/models"
 	"fmt"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
@@ -17,17 +20,23 @@ type MainWindow struct {
 	window             fyne.Window
 	animeController    *controllers.AnimeController
 	ringToneController *controllers.RingToneController
+	episodeController  *controllers.EpisodeController
+	historyController  *controllers.HistoryController
+	controllers        *controllers.Controllers
 }
 
-// NewMainWindow creates a new main window (receives app from main.go)
-func NewMainWindow(app fyne.App) *MainWindow {
+// NewMainWindow creates a new main window (receives app and controllers from main.go)
+func NewMainWindow(app fyne.App, ctrl *controllers.Controllers) *MainWindow {
 	w := app.NewWindow("Anime Reminder")
 	w.Resize(fyne.NewSize(800, 600))
 
 	return &MainWindow{
 		window:             w,
-		animeController:    &controllers.AnimeController{},
-		ringToneController: &controllers.RingToneController{},
+		animeController:    ctrl.Anime,
+		ringToneController: ctrl.RingTone,
+		episodeController:  ctrl.Episode,
+		historyController:  ctrl.History,
+		controllers:        ctrl,
 	}
 }
 
@@ -52,6 +61,7 @@ func (mw *MainWindow) Show() {
 		container.NewTabItem("Anime List", mw.createAnimeListTab()),
 		container.NewTabItem("Add Anime", mw.createAddAnimeTab()),
 		container.NewTabItem("Ringtones", mw.createRingToneTab()),
+		container.NewTabItem("History", mw.createHistoryTab()),
 		container.NewTabItem("Settings", mw.createSettingsTab()),
 	)
 
@@ -65,6 +75,7 @@ func (mw *MainWindow) ShowAndRun() {
 		container.NewTabItem("Anime List", mw.createAnimeListTab()),
 		container.NewTabItem("Add Anime", mw.createAddAnimeTab()),
 		container.NewTabItem("Ringtones", mw.createRingToneTab()),
+		container.NewTabItem("History", mw.createHistoryTab()),
 		container.NewTabItem("Settings", mw.createSettingsTab()),
 	)
 
@@ -82,7 +93,9 @@ func (mw *MainWindow) createAnimeListTab() fyne.CanvasObject {
 		},
 		func() fyne.CanvasObject {
 			return container.NewHBox(
+				newCoverImage(coverThumbSize),
 				widget.NewLabel("Template"),
+				widget.NewButton("Episodes", func() {}),
 				widget.NewButton("Edit", func() {}),
 				widget.NewButton("Delete", func() {}),
 			)
@@ -93,15 +106,31 @@ func (mw *MainWindow) createAnimeListTab() fyne.CanvasObject {
 				anime := animes[id]
 
 				cont := item.(*fyne.Container)
-				label := cont.Objects[0].(*widget.Label)
-				label.SetText(fmt.Sprintf("%s - %s at %s", anime.Title, anime.Day, anime.Time.Format("15:04")))
+				setCover(cont.Objects[0].(*canvas.Image), anime.ImagePath)
+
+				label := cont.Objects[1].(*widget.Label)
+				text := fmt.Sprintf("%s - %s", anime.Title, formatSlots(anime))
+				if anime.Archived {
+					text = "[archived] " + text
+				} else if !anime.HasPremiered(time.Now()) {
+					text += fmt.Sprintf(" (premieres %s)", anime.PremiereDate.Format("2006-01-02"))
+				}
+				if backlog, err := mw.episodeController.Backlog(anime.Id); err == nil && backlog > 0 {
+					text += fmt.Sprintf(" (%d unwatched)", backlog)
+				}
+				label.SetText(text)
 
-				editBtn := cont.Objects[1].(*widget.Button)
+				episodesBtn := cont.Objects[2].(*widget.Button)
+				episodesBtn.OnTapped = func() {
+					mw.showEpisodesDialog(anime, animeList.Refresh)
+				}
+
+				editBtn := cont.Objects[3].(*widget.Button)
 				editBtn.OnTapped = func() {
 					mw.showEditAnimeDialog(anime)
 				}
 
-				deleteBtn := cont.Objects[2].(*widget.Button)
+				deleteBtn := cont.Objects[4].(*widget.Button)
 				deleteBtn.OnTapped = func() {
 					mw.deleteAnime(anime.Id)
 					animeList.Refresh()
@@ -121,14 +150,20 @@ func (mw *MainWindow) createAddAnimeTab() fyne.CanvasObject {
 	titleEntry := widget.NewEntry()
 	titleEntry.SetPlaceHolder("Anime Title")
 
-	daySelect := widget.NewSelect(models.Days, func(value string) {})
-	daySelect.PlaceHolder = "Select Day"
+	timezoneEntry := widget.NewSelectEntry(models.CommonTimezones)
+	timezoneEntry.SetPlaceHolder("Broadcast timezone (empty = local)")
+
+	premiereEntry := widget.NewEntry()
+	premiereEntry.SetPlaceHolder("YYYY-MM-DD (optional)")
 
-	hourEntry := widget.NewEntry()
-	hourEntry.SetPlaceHolder("Hour (00-23)")
+	episodeCountEntry := widget.NewEntry()
+	episodeCountEntry.SetPlaceHolder("Total episodes (optional)")
 
-	minuteEntry := widget.NewEntry()
-	minuteEntry.SetPlaceHolder("Minute (00-59)")
+	endDateEntry := widget.NewEntry()
+	endDateEntry.SetPlaceHolder("YYYY-MM-DD (optional)")
+
+	streamURLEntry := widget.NewEntry()
+	streamURLEntry.SetPlaceHolder("https://... (optional)")
 
 	imagePathLabel := widget.NewLabel("No image selected")
 	var selectedImagePath string
@@ -167,45 +202,73 @@ func (mw *MainWindow) createAddAnimeTab() fyne.CanvasObject {
 	})
 	ringToneSelect.PlaceHolder = "Select Ringtone"
 
+	slots := newSlotEditor(ringTones, nil)
+	offsets := newOffsetEditor(ringTones, nil)
+
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "Title", Widget: titleEntry},
-			{Text: "Day", Widget: daySelect},
-			{Text: "Hour", Widget: hourEntry},
-			{Text: "Minute", Widget: minuteEntry},
+			{Text: "Timezone", Widget: timezoneEntry},
+			{Text: "Schedule", Widget: slots.Widget()},
+			{Text: "Premiere", Widget: premiereEntry},
+			{Text: "Episodes", Widget: episodeCountEntry},
+			{Text: "End Date", Widget: endDateEntry},
+			{Text: "Stream Link", Widget: streamURLEntry, HintText: "Opened when a phone notification is tapped"},
 			{Text: "Image", Widget: container.NewVBox(selectImageBtn, imagePathLabel)},
-			{Text: "Ringtone", Widget: ringToneSelect},
+			{Text: "Default Ringtone", Widget: ringToneSelect},
+			{Text: "Advance Reminders", Widget: offsets.Widget(), HintText: "Empty uses the default from Settings"},
 		},
 		OnSubmit: func() {
-			if titleEntry.Text == "" || daySelect.Selected == "" {
+			if titleEntry.Text == "" {
 				dialog.ShowError(fmt.Errorf("please fill all required fields"), mw.window)
 				return
 			}
 
-			var hour, minute int
-			fmt.Sscanf(hourEntry.Text, "%d", &hour)
-			fmt.Sscanf(minuteEntry.Text, "%d", &minute)
+			animeSlots, err := slots.Slots()
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
 
-			animeTime := time.Date(0, 1, 1, hour, minute, 0, 0, time.Local)
+			season, err := parseSeason(premiereEntry.Text, episodeCountEntry.Text, endDateEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
 
-			_, err := mw.animeController.Create(
-				titleEntry.Text,
-				daySelect.Selected,
-				selectedImagePath,
-				animeTime,
-				selectedRingToneId,
-			)
+			animeOffsets, err := offsets.Offsets()
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			anime, err := mw.animeController.Create(controllers.AnimeInput{
+				Title:      titleEntry.Text,
+				ImagePath:  selectedImagePath,
+				Timezone:   timezoneEntry.Text,
+				StreamURL:  strings.TrimSpace(streamURLEntry.Text),
+				Slots:      animeSlots,
+				RingToneId: selectedRingToneId,
+				Season:     season,
+				Offsets:    animeOffsets,
+			})
 
 			if err != nil {
 				dialog.ShowError(err, mw.window)
 				return
 			}
 
+			mw.syncEpisodes(anime)
+
 			dialog.ShowInformation("Success", "Anime added successfully!", mw.window)
 			titleEntry.SetText("")
-			daySelect.SetSelected("")
-			hourEntry.SetText("")
-			minuteEntry.SetText("")
+			timezoneEntry.SetText("")
+			slots.Reset()
+			offsets.Reset()
+			premiereEntry.SetText("")
+			episodeCountEntry.SetText("")
+			endDateEntry.SetText("")
+			streamURLEntry.SetText("")
 			imagePathLabel.SetText("No image selected")
 			selectedImagePath = ""
 			ringToneSelect.ClearSelected()
@@ -319,14 +382,27 @@ func (mw *MainWindow) showEditAnimeDialog(anime models.Anime) {
 	titleEntry := widget.NewEntry()
 	titleEntry.SetText(anime.Title)
 
-	daySelect := widget.NewSelect(models.Days, func(value string) {})
-	daySelect.SetSelected(anime.Day)
+	timezoneEntry := widget.NewSelectEntry(models.CommonTimezones)
+	timezoneEntry.SetPlaceHolder("Broadcast timezone (empty = local)")
+	timezoneEntry.SetText(anime.Timezone)
+
+	premiereEntry := widget.NewEntry()
+	premiereEntry.SetPlaceHolder("YYYY-MM-DD (optional)")
+	premiereEntry.SetText(formatOptionalDate(anime.PremiereDate))
+
+	episodeCountEntry := widget.NewEntry()
+	episodeCountEntry.SetPlaceHolder("Total episodes (optional)")
+	if anime.EpisodeCount > 0 {
+		episodeCountEntry.SetText(fmt.Sprintf("%d", anime.EpisodeCount))
+	}
 
-	hourEntry := widget.NewEntry()
-	hourEntry.SetText(fmt.Sprintf("%02d", anime.Time.Hour()))
+	endDateEntry := widget.NewEntry()
+	endDateEntry.SetPlaceHolder("YYYY-MM-DD (optional)")
+	endDateEntry.SetText(formatOptionalDate(anime.EndDate))
 
-	minuteEntry := widget.NewEntry()
-	minuteEntry.SetText(fmt.Sprintf("%02d", anime.Time.Minute()))
+	streamURLEntry := widget.NewEntry()
+	streamURLEntry.SetPlaceHolder("https://... (optional)")
+	streamURLEntry.SetText(anime.StreamURL)
 
 	imagePathLabel := widget.NewLabel(anime.ImagePath)
 	selectedImagePath := anime.ImagePath
@@ -364,42 +440,65 @@ func (mw *MainWindow) showEditAnimeDialog(anime models.Anime) {
 		selectedRingToneId = ringToneMap[value]
 	})
 
+	slots := newSlotEditor(ringTones, anime.Slots)
+	offsets := newOffsetEditor(ringTones, anime.ReminderOffsets)
+
 	form := &widget.Form{
 		Items: []*widget.FormItem{
 			{Text: "Title", Widget: titleEntry},
-			{Text: "Day", Widget: daySelect},
-			{Text: "Hour", Widget: hourEntry},
-			{Text: "Minute", Widget: minuteEntry},
+			{Text: "Timezone", Widget: timezoneEntry},
+			{Text: "Schedule", Widget: slots.Widget()},
+			{Text: "Premiere", Widget: premiereEntry},
+			{Text: "Episodes", Widget: episodeCountEntry},
+			{Text: "End Date", Widget: endDateEntry},
+			{Text: "Stream Link", Widget: streamURLEntry, HintText: "Opened when a phone notification is tapped"},
 			{Text: "Image", Widget: container.NewVBox(selectImageBtn, imagePathLabel)},
-			{Text: "Ringtone", Widget: ringToneSelect},
+			{Text: "Default Ringtone", Widget: ringToneSelect},
+			{Text: "Advance Reminders", Widget: offsets.Widget(), HintText: "Empty uses the default from Settings"},
 		},
 		OnSubmit: func() {
-			var hour, minute int
-			fmt.Sscanf(hourEntry.Text, "%d", &hour)
-			fmt.Sscanf(minuteEntry.Text, "%d", &minute)
-
-			animeTime := time.Date(0, 1, 1, hour, minute, 0, 0, time.Local)
-
-			_, err := mw.animeController.UpdateAnime(
-				titleEntry.Text,
-				daySelect.Selected,
-				selectedImagePath,
-				animeTime,
-				anime.Id,
-				selectedRingToneId,
-			)
+			animeSlots, err := slots.Slots()
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			season, err := parseSeason(premiereEntry.Text, episodeCountEntry.Text, endDateEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			animeOffsets, err := offsets.Offsets()
+			if err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+
+			updated, err := mw.animeController.UpdateAnime(anime.Id, controllers.AnimeInput{
+				Title:      titleEntry.Text,
+				ImagePath:  selectedImagePath,
+				Timezone:   timezoneEntry.Text,
+				StreamURL:  strings.TrimSpace(streamURLEntry.Text),
+				Slots:      animeSlots,
+				RingToneId: selectedRingToneId,
+				Season:     season,
+				Offsets:    animeOffsets,
+			})
 
 			if err != nil {
 				dialog.ShowError(err, mw.window)
 				return
 			}
 
+			mw.syncEpisodes(updated)
+
 			dialog.ShowInformation("Success", "Anime updated successfully!", mw.window)
 		},
 	}
 
-	d := dialog.NewCustom("Edit Anime", "Close", form, mw.window)
-	d.Resize(fyne.NewSize(400, 500))
+	d := dialog.NewCustom("Edit Anime", "Close", container.NewVScroll(form), mw.window)
+	d.Resize(fyne.NewSize(760, 600))
 	d.Show()
 }
 
@@ -416,3 +515,67 @@ func (mw *MainWindow) deleteAnime(id uint) {
 		}, mw.window)
 	confirm.Show()
 }
+
+// syncEpisodes membuat ulang jadwal episode jika premiere dan jumlah episode diketahui
+func (mw *MainWindow) syncEpisodes(anime *models.Anime) {
+	if anime.PremiereDate == nil || anime.EpisodeCount <= 0 {
+		return
+	}
+	if _, err := mw.episodeController.GenerateEpisodes(anime, *anime.PremiereDate, anime.EpisodeCount); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to generate episodes: %v", err), mw.window)
+	}
+}
+
+// parseSeason membaca input premiere, jumlah episode dan end date (semuanya opsional)
+func parseSeason(premiereText, episodeCountText, endDateText string) (models.Season, error) {
+	var season models.Season
+	var err error
+
+	if season.PremiereDate, err = parseOptionalDate(premiereText); err != nil {
+		return season, fmt.Errorf("invalid premiere date, use YYYY-MM-DD")
+	}
+	if season.EndDate, err = parseOptionalDate(endDateText); err != nil {
+		return season, fmt.Errorf("invalid end date, use YYYY-MM-DD")
+	}
+	if strings.TrimSpace(episodeCountText) != "" {
+		if season.EpisodeCount, err = strconv.Atoi(strings.TrimSpace(episodeCountText)); err != nil {
+			return season, fmt.Errorf("invalid episode count")
+		}
+	}
+	return season, nil
+}
+
+// parseOptionalDate mengembalikan nil untuk input kosong
+func parseOptionalDate(text string) (*time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", text, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func formatOptionalDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.In(time.Local).Format("2006-01-02")
+}
+
+// formatSlots menampilkan semua slot yang aktif (waktu siaran dan waktu lokal), dipisah koma
+func formatSlots(anime models.Anime) string {
+	now := time.Now()
+	parts := make([]string, 0, len(anime.Slots))
+	for _, slot := range anime.Slots {
+		if slot.Enabled {
+			parts = append(parts, anime.SlotSummary(slot, now))
+		}
+	}
+	if len(parts) == 0 {
+		return "no active slot"
+	}
+	return strings.Join(parts, ", ")
+}
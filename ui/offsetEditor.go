@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const noRingToneOption = "No sound"
+
+// offsetEditor adalah form untuk mengedit daftar advance reminder (ReminderOffset)
+type offsetEditor struct {
+	ringToneNames []string
+	ringToneMap   map[string]uint
+	ringToneByID  map[uint]string
+
+	rows []*offsetRow
+	box  *fyne.Container
+}
+
+// offsetRow adalah satu baris offset di editor
+type offsetRow struct {
+	minutes   *widget.Entry
+	template  *widget.Entry
+	ringTone  *widget.Select
+	container fyne.CanvasObject
+}
+
+func newOffsetEditor(ringTones []models.RingTone, offsets []models.ReminderOffset) *offsetEditor {
+	oe := &offsetEditor{
+		ringToneNames: []string{noRingToneOption},
+		ringToneMap:   make(map[string]uint),
+		ringToneByID:  make(map[uint]string),
+		box:           container.NewVBox(),
+	}
+	for _, rt := range ringTones {
+		oe.ringToneNames = append(oe.ringToneNames, rt.Name)
+		oe.ringToneMap[rt.Name] = rt.Id
+		oe.ringToneByID[rt.Id] = rt.Name
+	}
+
+	for _, offset := range offsets {
+		oe.addRow(offset)
+	}
+	return oe
+}
+
+// Widget returns the editor with an "Add Offset" button
+func (oe *offsetEditor) Widget() fyne.CanvasObject {
+	addBtn := widget.NewButton("Add Offset", func() {
+		oe.addRow(models.ReminderOffset{})
+	})
+	return container.NewVBox(oe.box, addBtn)
+}
+
+func (oe *offsetEditor) addRow(offset models.ReminderOffset) {
+	row := &offsetRow{}
+
+	row.minutes = widget.NewEntry()
+	row.minutes.SetPlaceHolder("Minutes before")
+	if offset.Minutes > 0 {
+		row.minutes.SetText(fmt.Sprintf("%d", offset.Minutes))
+	}
+
+	row.template = widget.NewEntry()
+	row.template.SetPlaceHolder(models.DefaultOffsetTemplate)
+	row.template.SetText(offset.Template)
+
+	row.ringTone = widget.NewSelect(oe.ringToneNames, func(value string) {})
+	if name, ok := oe.ringToneByID[offset.RingToneId]; ok {
+		row.ringTone.SetSelected(name)
+	} else {
+		row.ringTone.SetSelected(noRingToneOption)
+	}
+
+	removeBtn := widget.NewButton("Remove", func() {
+		oe.removeRow(row)
+	})
+
+	row.container = container.NewBorder(nil, nil, row.minutes, container.NewHBox(row.ringTone, removeBtn), row.template)
+
+	oe.rows = append(oe.rows, row)
+	oe.box.Add(row.container)
+}
+
+func (oe *offsetEditor) removeRow(row *offsetRow) {
+	for i, r := range oe.rows {
+		if r == row {
+			oe.rows = append(oe.rows[:i], oe.rows[i+1:]...)
+			break
+		}
+	}
+	oe.box.Remove(row.container)
+}
+
+// Reset mengosongkan editor
+func (oe *offsetEditor) Reset() {
+	oe.rows = nil
+	oe.box.RemoveAll()
+}
+
+// Offsets membaca semua baris menjadi ReminderOffset
+func (oe *offsetEditor) Offsets() ([]models.ReminderOffset, error) {
+	offsets := make([]models.ReminderOffset, 0, len(oe.rows))
+	for i, row := range oe.rows {
+		var minutes int
+		if _, err := fmt.Sscanf(strings.TrimSpace(row.minutes.Text), "%d", &minutes); err != nil {
+			return nil, fmt.Errorf("offset %d: please enter the minutes before airing", i+1)
+		}
+
+		offset := models.ReminderOffset{
+			Minutes:    minutes,
+			Template:   strings.TrimSpace(row.template.Text),
+			RingToneId: oe.ringToneMap[row.ringTone.Selected],
+		}
+		if err := offset.Validate(); err != nil {
+			return nil, fmt.Errorf("offset %d: %v", i+1, err)
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets, nil
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// quietHoursEditor adalah form untuk mengedit daftar quiet hours (QuietWindow)
+type quietHoursEditor struct {
+	rows []*quietRow
+	box  *fyne.Container
+}
+
+// quietRow adalah satu window di editor
+type quietRow struct {
+	day       *widget.Select
+	start     *widget.Entry
+	end       *widget.Entry
+	mode      *widget.Select
+	volume    *widget.Entry
+	container fyne.CanvasObject
+}
+
+func newQuietHoursEditor(windows []models.QuietWindow) *quietHoursEditor {
+	qe := &quietHoursEditor{box: container.NewVBox()}
+	for _, w := range windows {
+		qe.addRow(w)
+	}
+	return qe
+}
+
+// quietModeOptions returns the mode labels in models.QuietModes order
+func quietModeOptions() []string {
+	options := make([]string, len(models.QuietModes))
+	for i, mode := range models.QuietModes {
+		options[i] = mode.Label()
+	}
+	return options
+}
+
+// Widget returns the editor with an "Add Quiet Hours" button
+func (qe *quietHoursEditor) Widget() fyne.CanvasObject {
+	addBtn := widget.NewButton("Add Quiet Hours", func() {
+		qe.addRow(models.QuietWindow{Day: -1, Mode: models.QuietMuteSound})
+	})
+	return container.NewVBox(qe.box, addBtn)
+}
+
+func (qe *quietHoursEditor) addRow(w models.QuietWindow) {
+	row := &quietRow{}
+
+	row.day = widget.NewSelect(dayOptions(), func(value string) {})
+	if w.Day.Valid() {
+		row.day.SetSelected(w.Day.String())
+	}
+
+	row.start = widget.NewEntry()
+	row.start.SetPlaceHolder("Start (23:00)")
+	row.start.SetText(w.Start)
+
+	row.end = widget.NewEntry()
+	row.end.SetPlaceHolder("End (07:00)")
+	row.end.SetText(w.End)
+
+	row.volume = widget.NewEntry()
+	row.volume.SetPlaceHolder(fmt.Sprintf("Volume %% (%d)", models.DefaultQuietVolume))
+	if w.Volume > 0 {
+		row.volume.SetText(fmt.Sprintf("%d", w.Volume))
+	}
+
+	row.mode = widget.NewSelect(quietModeOptions(), func(value string) {
+		// Volume hanya berlaku untuk mode reduced volume
+		if value == models.QuietReducedVolume.Label() {
+			row.volume.Enable()
+		} else {
+			row.volume.Disable()
+		}
+	})
+	row.mode.SetSelected(w.Mode.Label())
+
+	removeBtn := widget.NewButton("Remove", func() {
+		qe.removeRow(row)
+	})
+
+	times := container.NewGridWithColumns(2, row.start, row.end)
+	row.container = container.NewBorder(nil, nil, row.day,
+		container.NewHBox(row.mode, row.volume, removeBtn), times)
+
+	qe.rows = append(qe.rows, row)
+	qe.box.Add(row.container)
+}
+
+func (qe *quietHoursEditor) removeRow(row *quietRow) {
+	for i, r := range qe.rows {
+		if r == row {
+			qe.rows = append(qe.rows[:i], qe.rows[i+1:]...)
+			break
+		}
+	}
+	qe.box.Remove(row.container)
+}
+
+// Windows membaca semua baris menjadi QuietWindow
+func (qe *quietHoursEditor) Windows() ([]models.QuietWindow, error) {
+	windows := make([]models.QuietWindow, 0, len(qe.rows))
+	for i, row := range qe.rows {
+		day, err := models.ParseWeekday(row.day.Selected)
+		if err != nil {
+			return nil, fmt.Errorf("quiet hours %d: please select a day", i+1)
+		}
+
+		w := models.QuietWindow{
+			Day:   day,
+			Start: strings.TrimSpace(row.start.Text),
+			End:   strings.TrimSpace(row.end.Text),
+		}
+		for _, mode := range models.QuietModes {
+			if mode.Label() == row.mode.Selected {
+				w.Mode = mode
+			}
+		}
+		if w.Mode == models.QuietReducedVolume && strings.TrimSpace(row.volume.Text) != "" {
+			if _, err := fmt.Sscanf(strings.TrimSpace(row.volume.Text), "%d", &w.Volume); err != nil {
+				return nil, fmt.Errorf("quiet hours %d: volume must be a number", i+1)
+			}
+			// 0 yang diketik user bukan "default", gunakan Mute sound untuk tanpa suara
+			if w.Volume == 0 {
+				return nil, fmt.Errorf("quiet hours %d: volume must be between 1 and 100 (use Mute sound for silence)", i+1)
+			}
+		}
+
+		if err := w.Validate(); err != nil {
+			return nil, fmt.Errorf("quiet hours %d: %v", i+1, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/scheduler"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ListenForReminders menampilkan popup snooze/dismiss setiap kali reminder di-fire,
+// dan mendaftarkan popup sebagai fallback terakhir notifier
+func (mw *MainWindow) ListenForReminders(app fyne.App) {
+	scheduler.OnReminder(func(event models.ReminderEvent) {
+		// Callback datang dari goroutine scheduler, UI harus diubah di goroutine fyne
+		fyne.Do(func() {
+			mw.showReminderPopup(app, event)
+		})
+	})
+
+	// Tombol "Open" / klik pada notifikasi menampilkan jendela utama
+	scheduler.OnOpen(func(animeID uint) {
+		fyne.Do(func() {
+			mw.Show()
+			mw.window.RequestFocus()
+		})
+	})
+
+	notifier.SetPopupHandler(func(n notifier.Notification) error {
+		// Reminder dengan tombol snooze/dismiss sudah punya popup sendiri lewat OnReminder
+		if n.AnimeId != 0 && len(n.Actions) > 0 {
+			return nil
+		}
+		fyne.Do(func() {
+			showNotificationPopup(app, n)
+		})
+		return nil
+	})
+}
+
+// showNotificationPopup menampilkan notifikasi biasa sebagai jendela kecil
+func showNotificationPopup(app fyne.App, n notifier.Notification) {
+	popup := app.NewWindow(n.Title)
+
+	body := widget.NewLabel(n.Body)
+	body.Wrapping = fyne.TextWrapWord
+
+	buttons := container.NewHBox()
+	for _, action := range n.Actions {
+		action := action
+		buttons.Add(widget.NewButton(action.Label, func() {
+			if n.OnAction != nil {
+				n.OnAction(action.Key)
+			}
+			popup.Close()
+		}))
+	}
+	buttons.Add(widget.NewButton("Close", func() {
+		popup.Close()
+	}))
+
+	// Icon sudah berupa cover yang di-resize (lihat utils.CoverIcon)
+	cover := newCoverImage(coverThumbSize * 2)
+	cover.File = n.Icon
+
+	popup.SetContent(container.NewVBox(
+		widget.NewLabelWithStyle(n.Title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		withCover(cover, body),
+		widget.NewSeparator(),
+		buttons,
+	))
+	popup.Resize(fyne.NewSize(380, 160))
+	popup.CenterOnScreen()
+	popup.Show()
+}
+
+// showReminderPopup membuka jendela kecil terpisah, supaya tetap muncul walaupun
+// jendela utama sedang di-hide ke tray
+func (mw *MainWindow) showReminderPopup(app fyne.App, event models.ReminderEvent) {
+	popup := app.NewWindow(event.Title)
+
+	message := widget.NewLabel(event.Message)
+	message.Wrapping = fyne.TextWrapWord
+
+	snooze := func(d time.Duration) {
+		if _, err := scheduler.SnoozeReminder(mw.controllers, event.Id, d); err != nil {
+			dialog.ShowError(err, popup)
+			return
+		}
+		popup.Close()
+	}
+
+	snoozeButtons := container.NewHBox()
+	for _, d := range models.SnoozeDurations {
+		d := d
+		snoozeButtons.Add(widget.NewButton(fmt.Sprintf("Snooze %d min", int(d/time.Minute)), func() {
+			snooze(d)
+		}))
+	}
+
+	customEntry := widget.NewEntry()
+	customEntry.SetPlaceHolder("Minutes")
+	customBtn := widget.NewButton("Snooze", func() {
+		minutes, err := strconv.Atoi(strings.TrimSpace(customEntry.Text))
+		if err != nil || minutes <= 0 {
+			dialog.ShowError(fmt.Errorf("please enter the number of minutes"), popup)
+			return
+		}
+		snooze(time.Duration(minutes) * time.Minute)
+	})
+
+	dismissBtn := widget.NewButton("Dismiss", func() {
+		if err := scheduler.DismissReminder(mw.controllers, event.Id); err != nil {
+			log.Printf("⚠️ Failed to dismiss reminder: %v", err)
+		}
+		popup.Close()
+	})
+	dismissBtn.Importance = widget.HighImportance
+
+	actions := container.NewGridWithColumns(1, dismissBtn)
+	if event.EpisodeNumber > 0 {
+		watchedBtn := widget.NewButton("Watched", func() {
+			if err := scheduler.MarkWatched(mw.controllers, event.Id); err != nil {
+				dialog.ShowError(err, popup)
+				return
+			}
+			popup.Close()
+		})
+		actions = container.NewGridWithColumns(2, watchedBtn, dismissBtn)
+	}
+
+	cover := newCoverImage(coverThumbSize * 2)
+	if anime, err := mw.animeController.GetAnimeById(event.AnimeId); err == nil {
+		setCover(cover, anime.ImagePath)
+	}
+
+	popup.SetContent(container.NewVBox(
+		widget.NewLabelWithStyle(event.Title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		withCover(cover, message),
+		widget.NewSeparator(),
+		snoozeButtons,
+		container.NewBorder(nil, nil, nil, customBtn, customEntry),
+		actions,
+	))
+	popup.Resize(fyne.NewSize(380, 200))
+	popup.CenterOnScreen()
+	popup.Show()
+	popup.RequestFocus()
+}
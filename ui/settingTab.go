@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"anime-reminder/settings"
 	"anime-reminder/utils"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -15,6 +21,9 @@ import (
 
 const AppName = "AnimeReminder"
 
+// systemLocaleOption adalah pilihan "ikut locale sistem" untuk nama hari
+const systemLocaleOption = "System"
+
 func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 	// Auto-start toggle
 	autoStartCheck := widget.NewCheck("Run at system startup", nil)
@@ -41,13 +50,432 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 		}
 	}
 
-	// Test Notification Button
-	testNotifBtn := widget.NewButton("Test Notification", func() {
-		err := utils.SendNotification("🎬 Test Notification", "This is a test notification from Anime Reminder!")
+	// Bahasa nama hari, kosong = ikut locale sistem
+	localeOptions := []string{systemLocaleOption}
+	localeOptions = append(localeOptions, models.DayLocales()...)
+	dayLocaleSelect := widget.NewSelect(localeOptions, nil)
+	if locale := settings.Get().DayLocale; locale != "" {
+		dayLocaleSelect.SetSelected(locale)
+	} else {
+		dayLocaleSelect.SetSelected(systemLocaleOption)
+	}
+	dayLocaleSelect.OnChanged = func(value string) {
+		locale := value
+		if value == systemLocaleOption {
+			locale = ""
+		}
+		if err := settings.Update(func(s *settings.Settings) { s.DayLocale = locale }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		if locale == "" {
+			locale = models.DetectDayLocale()
+		}
+		models.SetDayLocale(locale)
+		log.Printf("🌐 Day names: %s", locale)
+	}
+
+	// Batas reminder terlambat yang masih dikirim satu per satu
+	graceOptions := map[string]int{
+		"5 minutes": 5, "15 minutes": 15, "30 minutes": 30, "1 hour": 60, "2 hours": 120,
+	}
+	graceSelect := widget.NewSelect([]string{"5 minutes", "15 minutes", "30 minutes", "1 hour", "2 hours"}, nil)
+	currentGrace := int(settings.Get().MissedGrace() / time.Minute)
+	for label, minutes := range graceOptions {
+		if minutes == currentGrace {
+			graceSelect.SetSelected(label)
+		}
+	}
+	graceSelect.OnChanged = func(value string) {
+		err := settings.Update(func(s *settings.Settings) { s.MissedGraceMinutes = graceOptions[value] })
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+		}
+	}
+
+	// Advance reminder default untuk anime yang tidak punya offset sendiri
+	ringTones, _ := mw.ringToneController.GetAllRingTone()
+	defaultOffsets := newOffsetEditor(ringTones, settings.Get().DefaultOffsets)
+	saveOffsetsBtn := widget.NewButton("Save Default Offsets", func() {
+		offsets, err := defaultOffsets.Offsets()
+		if err == nil {
+			err = models.ValidateOffsets(offsets)
+		}
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("notification test failed: %v", err), mw.window)
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		models.SortOffsets(offsets)
+		if err := settings.Update(func(s *settings.Settings) { s.DefaultOffsets = offsets }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		mw.controllers.NotifyChange()
+		dialog.ShowInformation("Success", "Default advance reminders saved.", mw.window)
+	})
+
+	// Quiet hours per hari: mute, silent atau volume kecil
+	quietHours := newQuietHoursEditor(settings.Get().QuietHours)
+	saveQuietBtn := widget.NewButton("Save Quiet Hours", func() {
+		windows, err := quietHours.Windows()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if err := settings.Update(func(s *settings.Settings) { s.QuietHours = windows }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		log.Printf("🌙 Quiet hours saved: %d window(s)", len(windows))
+		dialog.ShowInformation("Success", "Quiet hours saved.", mw.window)
+	})
+
+	// Webhook: salinan reminder ke channel chat (Discord, Slack, Matrix, ...)
+	webhooks := newWebhookEditor(mw.window, settings.Get().Webhooks)
+	saveWebhooksBtn := widget.NewButton("Save Webhooks", func() {
+		list, err := webhooks.Webhooks()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if err := settings.Update(func(s *settings.Settings) { s.Webhooks = list }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		log.Printf("🔗 Webhooks saved: %d", len(list))
+		dialog.ShowInformation("Success", "Webhooks saved.", mw.window)
+	})
+
+	// Telegram bot: reminder dengan tombol Watched / Snooze di chat
+	telegram := settings.Get().Telegram
+	telegramCheck := widget.NewCheck("Send reminders to Telegram", nil)
+	telegramCheck.SetChecked(telegram.Enabled)
+	telegramTokenEntry := widget.NewPasswordEntry()
+	telegramTokenEntry.SetPlaceHolder("123456:ABC-DEF...")
+	telegramTokenEntry.SetText(telegram.BotToken)
+	telegramChatEntry := widget.NewEntry()
+	telegramChatEntry.SetPlaceHolder("Chat ID or @channel")
+	telegramChatEntry.SetText(telegram.ChatId)
+	telegramURLEntry := widget.NewEntry()
+	telegramURLEntry.SetPlaceHolder(models.DefaultTelegramAPI)
+	telegramURLEntry.SetText(telegram.BaseURL)
+	telegramConfig := func() models.Telegram {
+		return models.Telegram{
+			Enabled:  telegramCheck.Checked,
+			BotToken: strings.TrimSpace(telegramTokenEntry.Text),
+			ChatId:   strings.TrimSpace(telegramChatEntry.Text),
+			BaseURL:  strings.TrimSpace(telegramURLEntry.Text),
+		}
+	}
+	saveTelegramBtn := widget.NewButton("Save Telegram", func() {
+		cfg := telegramConfig()
+		if err := cfg.Validate(); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if err := settings.Update(func(s *settings.Settings) { s.Telegram = cfg }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		dialog.ShowInformation("Success", "Telegram settings saved.", mw.window)
+	})
+	testTelegramBtn := widget.NewButton("Test", func() {
+		cfg := telegramConfig()
+		cfg.Enabled = true
+		if err := cfg.Validate(); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		go func() {
+			err := notifier.NewTelegram(cfg).Send(testNotification())
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("Telegram test failed: %v", err), mw.window)
+					return
+				}
+				dialog.ShowInformation("Success", "Test message sent to Telegram!", mw.window)
+			})
+		}()
+	})
+
+	// Email lewat SMTP untuk reminder dan digest
+	email := settings.Get().Email
+	emailCheck := widget.NewCheck("Send reminders by email", nil)
+	emailCheck.SetChecked(email.Enabled)
+	emailDigestCheck := widget.NewCheck("Also email the daily and weekly digest", nil)
+	emailDigestCheck.SetChecked(email.Digest)
+	emailHostEntry := widget.NewEntry()
+	emailHostEntry.SetPlaceHolder("smtp.example.com")
+	emailHostEntry.SetText(email.Host)
+	emailPortEntry := widget.NewEntry()
+	emailPortEntry.SetPlaceHolder("Default for security mode")
+	if email.Port > 0 {
+		emailPortEntry.SetText(strconv.Itoa(email.Port))
+	}
+	securityOptions := make([]string, len(models.EmailSecurities))
+	for i, mode := range models.EmailSecurities {
+		securityOptions[i] = string(mode)
+	}
+	emailSecuritySelect := widget.NewSelect(securityOptions, nil)
+	emailSecuritySelect.SetSelected(string(email.SecurityMode()))
+	emailUserEntry := widget.NewEntry()
+	emailUserEntry.SetPlaceHolder("Empty = no login")
+	emailUserEntry.SetText(email.Username)
+	emailPasswordEntry := widget.NewPasswordEntry()
+	emailPasswordEntry.SetText(email.Password)
+	emailFromEntry := widget.NewEntry()
+	emailFromEntry.SetPlaceHolder("Anime Reminder <me@example.com>")
+	emailFromEntry.SetText(email.From)
+	emailToEntry := widget.NewEntry()
+	emailToEntry.SetPlaceHolder("me@example.com, friend@example.com")
+	emailToEntry.SetText(email.To)
+	emailTextEntry := widget.NewMultiLineEntry()
+	emailTextEntry.SetPlaceHolder(models.DefaultEmailTextTemplate)
+	emailTextEntry.SetText(email.TextTemplate)
+	emailHTMLEntry := widget.NewMultiLineEntry()
+	emailHTMLEntry.SetPlaceHolder("Default HTML layout with cover")
+	emailHTMLEntry.SetText(email.HTMLTemplate)
+	emailConfig := func() (models.Email, error) {
+		cfg := models.Email{
+			Enabled:      emailCheck.Checked,
+			Host:         strings.TrimSpace(emailHostEntry.Text),
+			Security:     models.EmailSecurity(emailSecuritySelect.Selected),
+			Username:     strings.TrimSpace(emailUserEntry.Text),
+			Password:     emailPasswordEntry.Text,
+			From:         strings.TrimSpace(emailFromEntry.Text),
+			To:           strings.TrimSpace(emailToEntry.Text),
+			Digest:       emailDigestCheck.Checked,
+			TextTemplate: strings.TrimSpace(emailTextEntry.Text),
+			HTMLTemplate: strings.TrimSpace(emailHTMLEntry.Text),
+		}
+		if text := strings.TrimSpace(emailPortEntry.Text); text != "" {
+			port, err := strconv.Atoi(text)
+			if err != nil {
+				return cfg, fmt.Errorf("SMTP port must be a number")
+			}
+			cfg.Port = port
+		}
+		return cfg, cfg.Validate()
+	}
+	saveEmailBtn := widget.NewButton("Save Email", func() {
+		cfg, err := emailConfig()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		if err := settings.Update(func(s *settings.Settings) { s.Email = cfg }); err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		dialog.ShowInformation("Success", "Email settings saved.", mw.window)
+	})
+	testEmailBtn := widget.NewButton("Test", func() {
+		cfg, err := emailConfig()
+		if err == nil {
+			cfg.Enabled = true
+			err = cfg.Validate()
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		go func() {
+			err := notifier.NewEmail(cfg).Send(testNotification())
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("email test failed: %v", err), mw.window)
+					return
+				}
+				dialog.ShowInformation("Success", "Test email sent!", mw.window)
+			})
+		}()
+	})
+
+	// Push ke HP lewat ntfy / Gotify
+	ntfy := settings.Get().Ntfy
+	ntfyCheck := widget.NewCheck("Push reminders to ntfy", nil)
+	ntfyCheck.SetChecked(ntfy.Enabled)
+	ntfyServerEntry := widget.NewEntry()
+	ntfyServerEntry.SetPlaceHolder("https://ntfy.example.com")
+	ntfyServerEntry.SetText(ntfy.ServerURL)
+	ntfyTopicEntry := widget.NewEntry()
+	ntfyTopicEntry.SetPlaceHolder("anime-reminder-...")
+	ntfyTopicEntry.SetText(ntfy.Topic)
+	ntfyTokenEntry := widget.NewPasswordEntry()
+	ntfyTokenEntry.SetPlaceHolder("Access token (optional)")
+	ntfyTokenEntry.SetText(ntfy.Token)
+	ntfyPriorityEntry := newPriorityEntry(ntfy.PriorityMap())
+	ntfyTagsEntry := widget.NewEntry()
+	ntfyTagsEntry.SetPlaceHolder("e.g. popcorn")
+	ntfyTagsEntry.SetText(strings.Join(ntfy.Tags, ", "))
+	ntfyClickEntry := widget.NewEntry()
+	ntfyClickEntry.SetPlaceHolder("Used when the anime has no stream link (optional)")
+	ntfyClickEntry.SetText(ntfy.ClickURL)
+	ntfyConfig := func() (models.Ntfy, error) {
+		cfg := models.Ntfy{
+			Enabled:   ntfyCheck.Checked,
+			ServerURL: strings.TrimSpace(ntfyServerEntry.Text),
+			Topic:     strings.TrimSpace(ntfyTopicEntry.Text),
+			Token:     strings.TrimSpace(ntfyTokenEntry.Text),
+			Tags:      models.ParseTags(ntfyTagsEntry.Text),
+			ClickURL:  strings.TrimSpace(ntfyClickEntry.Text),
+		}
+		priorities, err := parsePriorities(ntfyPriorityEntry.Text)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Priorities = priorities
+		return cfg, cfg.Validate()
+	}
+
+	gotify := settings.Get().Gotify
+	gotifyCheck := widget.NewCheck("Push reminders to Gotify", nil)
+	gotifyCheck.SetChecked(gotify.Enabled)
+	gotifyServerEntry := widget.NewEntry()
+	gotifyServerEntry.SetPlaceHolder("https://gotify.example.com")
+	gotifyServerEntry.SetText(gotify.ServerURL)
+	gotifyTokenEntry := widget.NewPasswordEntry()
+	gotifyTokenEntry.SetPlaceHolder("App token")
+	gotifyTokenEntry.SetText(gotify.AppToken)
+	gotifyPriorityEntry := newPriorityEntry(gotify.PriorityMap())
+	gotifyTagsEntry := widget.NewEntry()
+	gotifyTagsEntry.SetPlaceHolder("e.g. popcorn")
+	gotifyTagsEntry.SetText(strings.Join(gotify.Tags, ", "))
+	gotifyClickEntry := widget.NewEntry()
+	gotifyClickEntry.SetPlaceHolder("Used when the anime has no stream link (optional)")
+	gotifyClickEntry.SetText(gotify.ClickURL)
+	gotifyConfig := func() (models.Gotify, error) {
+		cfg := models.Gotify{
+			Enabled:   gotifyCheck.Checked,
+			ServerURL: strings.TrimSpace(gotifyServerEntry.Text),
+			AppToken:  strings.TrimSpace(gotifyTokenEntry.Text),
+			Tags:      models.ParseTags(gotifyTagsEntry.Text),
+			ClickURL:  strings.TrimSpace(gotifyClickEntry.Text),
+		}
+		priorities, err := parsePriorities(gotifyPriorityEntry.Text)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Priorities = priorities
+		return cfg, cfg.Validate()
+	}
+
+	savePushBtn := widget.NewButton("Save Push", func() {
+		ntfyCfg, err := ntfyConfig()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		gotifyCfg, err := gotifyConfig()
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		err = settings.Update(func(s *settings.Settings) {
+			s.Ntfy = ntfyCfg
+			s.Gotify = gotifyCfg
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		dialog.ShowInformation("Success", "Push settings saved.", mw.window)
+	})
+	testPush := func(name string, send func() error) {
+		go func() {
+			err := send()
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("%s test failed: %v", name, err), mw.window)
+					return
+				}
+				dialog.ShowInformation("Success", fmt.Sprintf("Test notification sent via %s!", name), mw.window)
+			})
+		}()
+	}
+	testNtfyBtn := widget.NewButton("Test ntfy", func() {
+		cfg, err := ntfyConfig()
+		if err == nil {
+			cfg.Enabled = true
+			err = cfg.Validate()
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		testPush("ntfy", func() error { return notifier.NewNtfy(cfg).Send(testNotification()) })
+	})
+	testGotifyBtn := widget.NewButton("Test Gotify", func() {
+		cfg, err := gotifyConfig()
+		if err == nil {
+			cfg.Enabled = true
+			err = cfg.Validate()
+		}
+		if err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+		testPush("Gotify", func() error { return notifier.NewGotify(cfg).Send(testNotification()) })
+	})
+
+	// Digest harian (pagi) dan mingguan (Minggu malam)
+	current := settings.Get()
+	dailyDigestEntry := widget.NewEntry()
+	dailyDigestEntry.SetPlaceHolder("Off (e.g. 08:00)")
+	dailyDigestEntry.SetText(current.DailyDigestTime)
+	weeklyDigestCheck := widget.NewCheck("Send next week's schedule on Sunday", nil)
+	weeklyDigestCheck.SetChecked(current.WeeklyDigest)
+	weeklyDigestEntry := widget.NewEntry()
+	weeklyDigestEntry.SetPlaceHolder(models.DefaultWeeklyDigestTime)
+	weeklyDigestEntry.SetText(current.WeeklyDigestTime)
+	digestTemplateEntry := widget.NewEntry()
+	digestTemplateEntry.SetPlaceHolder(models.DefaultDigestTemplate)
+	digestTemplateEntry.SetText(current.DigestTemplate)
+	saveDigestBtn := widget.NewButton("Save Digest", func() {
+		daily := strings.TrimSpace(dailyDigestEntry.Text)
+		weekly := strings.TrimSpace(weeklyDigestEntry.Text)
+		tmpl := strings.TrimSpace(digestTemplateEntry.Text)
+		for _, text := range []string{daily, weekly} {
+			if text == "" {
+				continue
+			}
+			if _, err := models.ParseClock(text); err != nil {
+				dialog.ShowError(err, mw.window)
+				return
+			}
+		}
+		if err := models.ValidateDigestTemplate(tmpl); err != nil {
+			dialog.ShowError(err, mw.window)
+			return
+		}
+
+		err := settings.Update(func(s *settings.Settings) {
+			s.DailyDigestTime = daily
+			s.WeeklyDigest = weeklyDigestCheck.Checked
+			s.WeeklyDigestTime = weekly
+			s.DigestTemplate = tmpl
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			return
+		}
+		mw.controllers.NotifyChange()
+		dialog.ShowInformation("Success", "Digest settings saved.", mw.window)
+	})
+
+	// Dry run: daftar reminder yang akan di-fire, tanpa mengirim apa pun
+	dryRunBtn := widget.NewButton("Dry Run (next 7 days)", func() {
+		mw.showDryRunDialog()
+	})
+
+	// Test Notification Button (lewat fallback chain seperti reminder sungguhan)
+	testNotifBtn := widget.NewButton("Test Notification", func() {
+		deliveries := notifier.Send(testNotification())
+		last := deliveries[len(deliveries)-1]
+		if last.Err != nil {
+			dialog.ShowError(fmt.Errorf("notification test failed: %v", last.Err), mw.window)
 		} else {
-			dialog.ShowInformation("Success", "Notification sent! Check your system tray.", mw.window)
+			dialog.ShowInformation("Success", fmt.Sprintf("Notification sent via %s!", last.Backend), mw.window)
 		}
 	})
 
@@ -63,7 +491,7 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 		firstRingTone := ringTones[0]
 
 		// Cek apakah file exists
-		if _, err := os.Stat(firstRingTone.SongPath); os.IsNotExist(err) {
+		if _, err := os.Stat(utils.ResolveDataPath(firstRingTone.SongPath)); os.IsNotExist(err) {
 			dialog.ShowError(fmt.Errorf("audio file not found: %s", firstRingTone.SongPath), mw.window)
 			return
 		}
@@ -71,7 +499,7 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 		utils.PlayAudioAsync(firstRingTone.SongPath, 10*time.Second)
 
 		// Get absolute path untuk ditampilkan
-		absPath, _ := filepath.Abs(firstRingTone.SongPath)
+		absPath, _ := filepath.Abs(utils.ResolveDataPath(firstRingTone.SongPath))
 
 		dialog.ShowInformation("Playing Audio",
 			fmt.Sprintf("Playing: %s\nDuration: 10 seconds\n\nFile: %s\n\n⚠️ If you don't hear anything:\n1. Check volume is not muted\n2. Check file format (MP3 recommended)\n3. Try playing file manually in Windows Media Player",
@@ -94,7 +522,8 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 	appInfo := widget.NewLabel(
 		"Anime Reminder v1.0\n\n" +
 			"This application helps you track anime schedules and sends reminders.\n\n" +
-			"When minimized, the app runs in the system tray and continues monitoring your anime schedule.",
+			"When minimized, the app runs in the system tray and continues monitoring your anime schedule.\n\n" +
+			"Data directory: " + utils.DataDir(),
 	)
 	appInfo.Wrapping = fyne.TextWrapWord
 
@@ -121,9 +550,105 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 			widget.NewLabel("Enable this to automatically run the app when your computer starts."),
 		)),
 		widget.NewSeparator(),
+		widget.NewCard("Display", "", container.NewVBox(
+			widget.NewForm(widget.NewFormItem("Day names", dayLocaleSelect)),
+			widget.NewLabel("Day names are only used for display, schedules are stored independently of the language."),
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Advance Reminders", "", container.NewVBox(
+			widget.NewLabel("Default reminders before a show starts, e.g. 30 and 5 minutes. Anime with their own offsets ignore these.\nTemplate fields: {{.Title}}, {{.Episode}}, {{.Slot}}, {{.Minutes}}, {{.Time}}"),
+			defaultOffsets.Widget(),
+			saveOffsetsBtn,
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Notifications", "", container.NewVBox(
+			widget.NewLabel("Backends are tried in this order, the first one that works is used."),
+			mw.notifierSettings(),
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Webhooks", "", container.NewVBox(
+			widget.NewLabel("Every reminder is also posted to each enabled webhook, in addition to the desktop notification.\nURLs may contain template fields, e.g. {{.TxnId}} for Matrix."),
+			webhooks.Widget(),
+			saveWebhooksBtn,
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Telegram", "", container.NewVBox(
+			telegramCheck,
+			widget.NewForm(
+				widget.NewFormItem("Bot token", telegramTokenEntry),
+				widget.NewFormItem("Chat", telegramChatEntry),
+				widget.NewFormItem("API URL", telegramURLEntry),
+			),
+			widget.NewLabel("Create a bot with @BotFather and send it a message first. Reminders get Watched and Snooze buttons that work while the app is running."),
+			container.NewHBox(saveTelegramBtn, testTelegramBtn),
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Email", "", container.NewVBox(
+			emailCheck,
+			emailDigestCheck,
+			widget.NewForm(
+				widget.NewFormItem("SMTP server", emailHostEntry),
+				widget.NewFormItem("Port", emailPortEntry),
+				widget.NewFormItem("Security", emailSecuritySelect),
+				widget.NewFormItem("Username", emailUserEntry),
+				widget.NewFormItem("Password", emailPasswordEntry),
+				widget.NewFormItem("From", emailFromEntry),
+				widget.NewFormItem("To", emailToEntry),
+				widget.NewFormItem("Text template", emailTextEntry),
+				widget.NewFormItem("HTML template", emailHTMLEntry),
+			),
+			widget.NewLabel("starttls uses port 587, tls port 465. Empty templates use the default layout with the anime cover.\nTemplate fields: {{.Title}}, {{.Body}}, {{.Lines}}, {{.AnimeTitle}}, {{.Episode}}, {{.AirTime}}, {{.Cover}}, {{.CoverCID}}"),
+			container.NewHBox(saveEmailBtn, testEmailBtn),
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Push Notifications", "", container.NewVBox(
+			ntfyCheck,
+			widget.NewForm(
+				widget.NewFormItem("Server", ntfyServerEntry),
+				widget.NewFormItem("Topic", ntfyTopicEntry),
+				widget.NewFormItem("Token", ntfyTokenEntry),
+				widget.NewFormItem("Priorities", ntfyPriorityEntry),
+				widget.NewFormItem("Tags", ntfyTagsEntry),
+				widget.NewFormItem("Click URL", ntfyClickEntry),
+			),
+			gotifyCheck,
+			widget.NewForm(
+				widget.NewFormItem("Server", gotifyServerEntry),
+				widget.NewFormItem("App token", gotifyTokenEntry),
+				widget.NewFormItem("Priorities", gotifyPriorityEntry),
+				widget.NewFormItem("Tags", gotifyTagsEntry),
+				widget.NewFormItem("Click URL", gotifyClickEntry),
+			),
+			widget.NewLabel("Priorities are low, normal, critical (ntfy 1-5, Gotify 1-10). Tags are emoji shortcodes such as tv or popcorn.\nTapping a notification opens the anime's stream link, or the click URL if it has none."),
+			container.NewHBox(savePushBtn, testNtfyBtn, testGotifyBtn),
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Digest", "", container.NewVBox(
+			widget.NewForm(
+				widget.NewFormItem("Daily digest at", dailyDigestEntry),
+				widget.NewFormItem("", weeklyDigestCheck),
+				widget.NewFormItem("Weekly digest at", weeklyDigestEntry),
+				widget.NewFormItem("Line template", digestTemplateEntry),
+			),
+			widget.NewLabel("Lists everything airing today (or next week) with local times. Days with nothing scheduled are skipped.\nTemplate fields: {{.Title}}, {{.Episode}}, {{.Slot}}, {{.Day}}, {{.Time}}"),
+			saveDigestBtn,
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Quiet Hours", "", container.NewVBox(
+			widget.NewLabel("Windows start on the selected day, an end before the start continues into the next morning (e.g. Friday 23:00 - 07:00).\nMute sound: notification only. Suppress everything: only recorded in History. Reduced volume: ringtone plays quieter."),
+			quietHours.Widget(),
+			saveQuietBtn,
+		)),
+		widget.NewSeparator(),
+		widget.NewCard("Missed Reminders", "", container.NewVBox(
+			widget.NewForm(widget.NewFormItem("Late reminder grace", graceSelect)),
+			widget.NewLabel("Reminders missed while the app was closed or the computer was asleep are sent late within this window.\nOlder ones are collected into a single \"you missed\" summary."),
+		)),
+		widget.NewSeparator(),
 		widget.NewCard("Testing", "", container.NewVBox(
 			widget.NewLabel("Test reminder features:"),
 			container.NewGridWithColumns(3, testNotifBtn, testAudioBtn, stopAudioBtn),
+			dryRunBtn,
 			widget.NewLabel("⚠️ Make sure you have added at least one ringtone before testing audio."),
 		)),
 		widget.NewSeparator(),
@@ -137,3 +662,74 @@ func (mw *MainWindow) createSettingsTab() fyne.CanvasObject {
 
 	return container.NewVScroll(content)
 }
+
+// testNotification adalah notifikasi untuk tombol test di Settings
+func testNotification() notifier.Notification {
+	return notifier.Notification{
+		Title:   "🎬 Test Notification",
+		Body:    "This is a test notification from Anime Reminder!",
+		Urgency: notifier.UrgencyNormal,
+	}
+}
+
+// newPriorityEntry menampilkan prioritas low, normal, critical sebagai "2, 3, 5"
+func newPriorityEntry(p models.PushPriorities) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("low, normal, critical")
+	entry.SetText(fmt.Sprintf("%d, %d, %d", p.Low, p.Normal, p.Critical))
+	return entry
+}
+
+// parsePriorities membaca "low, normal, critical", kosong berarti prioritas default
+func parsePriorities(text string) (models.PushPriorities, error) {
+	var p models.PushPriorities
+	if strings.TrimSpace(text) == "" {
+		return p, nil
+	}
+	parts := strings.Split(text, ",")
+	if len(parts) != 3 {
+		return p, fmt.Errorf("priorities must be three numbers: low, normal, critical")
+	}
+	values := []*int{&p.Low, &p.Normal, &p.Critical}
+	for i, part := range parts {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return p, fmt.Errorf("priorities must be three numbers: low, normal, critical")
+		}
+		*values[i] = value
+	}
+	return p, nil
+}
+
+// notifierSettings menampilkan satu baris per backend notifikasi: on/off dan tombol test
+func (mw *MainWindow) notifierSettings() fyne.CanvasObject {
+	rows := container.NewVBox()
+	for _, backend := range notifier.Backends() {
+		name := backend.Name()
+
+		enabledCheck := widget.NewCheck(name, nil)
+		enabledCheck.SetChecked(settings.Get().NotifierEnabled(name))
+		enabledCheck.OnChanged = func(checked bool) {
+			err := settings.Update(func(s *settings.Settings) { s.SetNotifierEnabled(name, checked) })
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("failed to save settings: %v", err), mw.window)
+			}
+		}
+
+		status := widget.NewLabel("")
+		testBtn := widget.NewButton("Test", func() {
+			if err := notifier.SendTo(name, testNotification()); err != nil {
+				dialog.ShowError(fmt.Errorf("%s test failed: %v", name, err), mw.window)
+				return
+			}
+			dialog.ShowInformation("Success", fmt.Sprintf("Notification sent via %s!", name), mw.window)
+		})
+		if !backend.Available() {
+			status.SetText("not available on this system")
+			testBtn.Disable()
+		}
+
+		rows.Add(container.NewBorder(nil, nil, enabledCheck, testBtn, status))
+	}
+	return rows
+}
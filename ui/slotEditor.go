@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const defaultRingToneOption = "Anime default"
+
+// slotEditor adalah form untuk mengedit daftar ScheduleSlot sebuah anime
+type slotEditor struct {
+	ringToneNames []string
+	ringToneMap   map[string]uint
+	ringToneByID  map[uint]string
+
+	rows []*slotRow
+	box  *fyne.Container
+}
+
+// slotRow adalah satu baris slot di editor
+type slotRow struct {
+	slot      models.ScheduleSlot
+	label     *widget.SelectEntry
+	day       *widget.Select
+	hour      *widget.Entry
+	minute    *widget.Entry
+	ringTone  *widget.Select
+	enabled   *widget.Check
+	container fyne.CanvasObject
+}
+
+func newSlotEditor(ringTones []models.RingTone, slots []models.ScheduleSlot) *slotEditor {
+	se := &slotEditor{
+		ringToneNames: []string{defaultRingToneOption},
+		ringToneMap:   make(map[string]uint),
+		ringToneByID:  make(map[uint]string),
+		box:           container.NewVBox(),
+	}
+	for _, rt := range ringTones {
+		se.ringToneNames = append(se.ringToneNames, rt.Name)
+		se.ringToneMap[rt.Name] = rt.Id
+		se.ringToneByID[rt.Id] = rt.Name
+	}
+
+	if len(slots) == 0 {
+		se.addRow(models.ScheduleSlot{Label: models.SlotLabels[0], Enabled: true})
+	}
+	for _, slot := range slots {
+		se.addRow(slot)
+	}
+	return se
+}
+
+// Widget returns the editor with an "Add Slot" button
+func (se *slotEditor) Widget() fyne.CanvasObject {
+	addBtn := widget.NewButton("Add Slot", func() {
+		se.addRow(models.ScheduleSlot{Enabled: true})
+	})
+	return container.NewVBox(se.box, addBtn)
+}
+
+func (se *slotEditor) addRow(slot models.ScheduleSlot) {
+	row := &slotRow{slot: slot}
+
+	row.label = widget.NewSelectEntry(models.SlotLabels)
+	row.label.SetPlaceHolder("Label")
+	row.label.SetText(slot.Label)
+
+	// Slot late-night ditampilkan dengan notasi penyiar (hari sebelumnya, jam 24+)
+	day, hour, minute := slot.BroadcastNotation()
+
+	row.day = widget.NewSelect(dayOptions(), func(value string) {})
+	row.day.PlaceHolder = "Day"
+	if slot.Id != 0 && slot.Day.Valid() {
+		row.day.SetSelected(day.String())
+	} else if slot.Id != 0 {
+		// Hari lama yang tidak dikenali saat migrasi, user harus memilih ulang
+		row.day.PlaceHolder = fmt.Sprintf("Invalid day %q", slot.InvalidDay)
+	}
+
+	row.hour = widget.NewEntry()
+	row.hour.SetPlaceHolder(fmt.Sprintf("Hour (00-%d)", models.MaxLateNightHour))
+	row.minute = widget.NewEntry()
+	row.minute.SetPlaceHolder("Minute (00-59)")
+	if slot.Id != 0 {
+		row.hour.SetText(fmt.Sprintf("%02d", hour))
+		row.minute.SetText(fmt.Sprintf("%02d", minute))
+	}
+
+	row.ringTone = widget.NewSelect(se.ringToneNames, func(value string) {})
+	if name, ok := se.ringToneByID[slot.RingToneId]; ok {
+		row.ringTone.SetSelected(name)
+	} else {
+		row.ringTone.SetSelected(defaultRingToneOption)
+	}
+
+	row.enabled = widget.NewCheck("Enabled", nil)
+	row.enabled.SetChecked(slot.Enabled)
+
+	removeBtn := widget.NewButton("Remove", func() {
+		se.removeRow(row)
+	})
+
+	row.container = container.NewGridWithColumns(7,
+		row.label, row.day, row.hour, row.minute, row.ringTone, row.enabled, removeBtn)
+
+	se.rows = append(se.rows, row)
+	se.box.Add(row.container)
+}
+
+func (se *slotEditor) removeRow(row *slotRow) {
+	for i, r := range se.rows {
+		if r == row {
+			se.rows = append(se.rows[:i], se.rows[i+1:]...)
+			break
+		}
+	}
+	se.box.Remove(row.container)
+}
+
+// Reset mengosongkan editor menjadi satu slot baru
+func (se *slotEditor) Reset() {
+	se.rows = nil
+	se.box.RemoveAll()
+	se.addRow(models.ScheduleSlot{Label: models.SlotLabels[0], Enabled: true})
+}
+
+// Slots membaca semua baris menjadi ScheduleSlot. Id slot lama dipertahankan.
+func (se *slotEditor) Slots() ([]models.ScheduleSlot, error) {
+	slots := make([]models.ScheduleSlot, 0, len(se.rows))
+	for i, row := range se.rows {
+		day, err := models.ParseWeekday(row.day.Selected)
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: please select a day", i+1)
+		}
+
+		hour, err := strconv.Atoi(strings.TrimSpace(row.hour.Text))
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: invalid hour %q (00-%d)", i+1, row.hour.Text, models.MaxLateNightHour)
+		}
+		minute, err := strconv.Atoi(strings.TrimSpace(row.minute.Text))
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: invalid minute %q (00-59)", i+1, row.minute.Text)
+		}
+
+		// Jam 24-29 digulung ke hari berikutnya oleh NewScheduleSlot
+		parsed, err := models.NewScheduleSlot(row.label.Text, day, hour, minute)
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: %v", i+1, err)
+		}
+
+		slot := row.slot
+		slot.Label = parsed.Label
+		slot.Day = parsed.Day
+		slot.InvalidDay = ""
+		slot.Time = parsed.Time
+		slot.LateNight = parsed.LateNight
+		slot.RingToneId = se.ringToneMap[row.ringTone.Selected]
+		slot.Enabled = row.enabled.Checked
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// dayOptions returns the day names in the display locale, starting from Monday
+func dayOptions() []string {
+	options := make([]string, len(models.WeekdayOrder))
+	for i, day := range models.WeekdayOrder {
+		options[i] = day.String()
+	}
+	return options
+}
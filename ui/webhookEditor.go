@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"anime-reminder/models"
+	"anime-reminder/notifier"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// webhookEditor adalah form untuk mengedit daftar webhook di settings
+type webhookEditor struct {
+	window fyne.Window
+	rows   []*webhookRow
+	box    *fyne.Container
+}
+
+// webhookRow adalah satu webhook di editor. Field lanjutan (method, header,
+// template, timeout, retry) diedit lewat dialog "Advanced".
+type webhookRow struct {
+	enabled   *widget.Check
+	name      *widget.Entry
+	preset    *widget.Select
+	url       *widget.Entry
+	advanced  models.Webhook
+	container fyne.CanvasObject
+}
+
+func newWebhookEditor(window fyne.Window, webhooks []models.Webhook) *webhookEditor {
+	we := &webhookEditor{window: window, box: container.NewVBox()}
+	for _, w := range webhooks {
+		we.addRow(w)
+	}
+	return we
+}
+
+// webhookPresetOptions returns the preset labels in models.WebhookPresets order
+func webhookPresetOptions() []string {
+	options := make([]string, len(models.WebhookPresets))
+	for i, preset := range models.WebhookPresets {
+		options[i] = preset.Label()
+	}
+	return options
+}
+
+// Widget returns the editor with an "Add Webhook" button
+func (we *webhookEditor) Widget() fyne.CanvasObject {
+	addBtn := widget.NewButton("Add Webhook", func() {
+		we.addRow(models.Webhook{Enabled: true, Preset: models.WebhookDiscord, Retries: 2})
+	})
+	return container.NewVBox(we.box, addBtn)
+}
+
+func (we *webhookEditor) addRow(w models.Webhook) {
+	row := &webhookRow{advanced: w}
+
+	row.enabled = widget.NewCheck("", nil)
+	row.enabled.SetChecked(w.Enabled)
+
+	row.name = widget.NewEntry()
+	row.name.SetPlaceHolder("Name")
+	row.name.SetText(w.Name)
+
+	row.url = widget.NewEntry()
+	row.url.SetText(w.URL)
+
+	row.preset = widget.NewSelect(webhookPresetOptions(), func(value string) {
+		row.url.SetPlaceHolder(presetByLabel(value).URLHint())
+	})
+	row.preset.SetSelected(w.Preset.Label())
+
+	advancedBtn := widget.NewButton("Advanced", func() {
+		we.showAdvancedDialog(row)
+	})
+	testBtn := widget.NewButton("Test", func() {
+		cfg := row.webhook()
+		if err := cfg.Validate(); err != nil {
+			dialog.ShowError(err, we.window)
+			return
+		}
+		// Request (dengan retry) bisa lama, jangan tahan UI
+		go func() {
+			err := notifier.NewWebhook(cfg).Send(testNotification())
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("webhook test failed: %v", err), we.window)
+					return
+				}
+				dialog.ShowInformation("Success", fmt.Sprintf("Test message sent to %s!", cfg.Name), we.window)
+			})
+		}()
+	})
+	removeBtn := widget.NewButton("Remove", func() {
+		we.removeRow(row)
+	})
+
+	left := container.NewHBox(row.enabled, container.NewGridWrap(fyne.NewSize(140, row.name.MinSize().Height), row.name), row.preset)
+	row.container = container.NewBorder(nil, nil, left,
+		container.NewHBox(advancedBtn, testBtn, removeBtn), row.url)
+
+	we.rows = append(we.rows, row)
+	we.box.Add(row.container)
+}
+
+func (we *webhookEditor) removeRow(row *webhookRow) {
+	for i, r := range we.rows {
+		if r == row {
+			we.rows = append(we.rows[:i], we.rows[i+1:]...)
+			break
+		}
+	}
+	we.box.Remove(row.container)
+}
+
+// presetByLabel mencari preset dari label Select, default custom
+func presetByLabel(label string) models.WebhookPreset {
+	for _, preset := range models.WebhookPresets {
+		if preset.Label() == label {
+			return preset
+		}
+	}
+	return models.WebhookCustom
+}
+
+// showAdvancedDialog mengedit method, header, template, timeout dan retry satu webhook
+func (we *webhookEditor) showAdvancedDialog(row *webhookRow) {
+	preset := presetByLabel(row.preset.Selected)
+
+	methodEntry := widget.NewEntry()
+	methodEntry.SetPlaceHolder(preset.Method())
+	methodEntry.SetText(row.advanced.Method)
+
+	headersEntry := widget.NewMultiLineEntry()
+	headersEntry.SetPlaceHolder("Authorization: Bearer <token>")
+	headersEntry.SetText(formatHeaders(row.advanced.Headers))
+
+	templateEntry := widget.NewMultiLineEntry()
+	templateEntry.SetPlaceHolder(preset.Template())
+	templateEntry.SetText(row.advanced.Template)
+	templateEntry.Wrapping = fyne.TextWrapWord
+	templateEntry.SetMinRowsVisible(5)
+
+	timeoutEntry := widget.NewEntry()
+	timeoutEntry.SetPlaceHolder(fmt.Sprintf("%d", int(models.DefaultWebhookTimeout.Seconds())))
+	if row.advanced.TimeoutSeconds > 0 {
+		timeoutEntry.SetText(strconv.Itoa(row.advanced.TimeoutSeconds))
+	}
+
+	retriesEntry := widget.NewEntry()
+	retriesEntry.SetText(strconv.Itoa(row.advanced.Retries))
+
+	form := widget.NewForm(
+		widget.NewFormItem("Method", methodEntry),
+		widget.NewFormItem("Headers", headersEntry),
+		widget.NewFormItem("Body template", templateEntry),
+		widget.NewFormItem("Timeout (seconds)", timeoutEntry),
+		widget.NewFormItem("Retries", retriesEntry),
+	)
+	help := widget.NewLabel("Empty fields use the preset. The body must be JSON, use {{json .Field}} to quote text.\nTemplate fields: {{.Title}}, {{.Body}}, {{.AnimeTitle}}, {{.Episode}}, {{.AirTime}}, {{.Urgency}}, {{.TxnId}}")
+	help.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm("Webhook Settings", "Apply", "Cancel", container.NewVBox(form, help), func(ok bool) {
+		if !ok {
+			return
+		}
+		headers, err := parseHeaders(headersEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, we.window)
+			return
+		}
+		timeout, retries := 0, 0
+		if text := strings.TrimSpace(timeoutEntry.Text); text != "" {
+			if timeout, err = strconv.Atoi(text); err != nil {
+				dialog.ShowError(fmt.Errorf("timeout must be a number"), we.window)
+				return
+			}
+		}
+		if text := strings.TrimSpace(retriesEntry.Text); text != "" {
+			if retries, err = strconv.Atoi(text); err != nil {
+				dialog.ShowError(fmt.Errorf("retries must be a number"), we.window)
+				return
+			}
+		}
+
+		row.advanced.Method = strings.ToUpper(strings.TrimSpace(methodEntry.Text))
+		row.advanced.Headers = headers
+		row.advanced.Template = strings.TrimSpace(templateEntry.Text)
+		row.advanced.TimeoutSeconds = timeout
+		row.advanced.Retries = retries
+	}, we.window)
+	d.Resize(fyne.NewSize(560, 480))
+	d.Show()
+}
+
+// formatHeaders menampilkan header sebagai baris "Key: Value"
+func formatHeaders(headers map[string]string) string {
+	lines := make([]string, 0, len(headers))
+	for key, value := range headers {
+		lines = append(lines, key+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseHeaders membaca baris "Key: Value", baris kosong diabaikan
+func parseHeaders(text string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header %q (use Key: Value)", line)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
+
+// webhook membaca baris menjadi models.Webhook (belum divalidasi)
+func (row *webhookRow) webhook() models.Webhook {
+	w := row.advanced
+	w.Enabled = row.enabled.Checked
+	w.Name = strings.TrimSpace(row.name.Text)
+	w.Preset = presetByLabel(row.preset.Selected)
+	w.URL = strings.TrimSpace(row.url.Text)
+	return w
+}
+
+// Webhooks membaca dan memvalidasi semua baris
+func (we *webhookEditor) Webhooks() ([]models.Webhook, error) {
+	webhooks := make([]models.Webhook, 0, len(we.rows))
+	for _, row := range we.rows {
+		webhooks = append(webhooks, row.webhook())
+	}
+	if err := models.ValidateWebhooks(webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os/exec"
@@ -18,6 +19,41 @@ type AudioPlayer struct {
 
 var globalPlayer = &AudioPlayer{}
 
+// ErrAudioMuted dikembalikan PlayAudio jika volume saat ini 0 (misalnya quiet hours)
+var ErrAudioMuted = errors.New("audio is muted")
+
+// volumeFunc mengembalikan volume (0-100) untuk audio berikutnya, lihat SetVolumeFunc
+var (
+	volumeMu   sync.RWMutex
+	volumeFunc func() int
+)
+
+// SetVolumeFunc mengatur sumber volume playback, misalnya profil quiet hours.
+// Tanpa fungsi ini audio selalu diputar dengan volume penuh.
+func SetVolumeFunc(fn func() int) {
+	volumeMu.Lock()
+	defer volumeMu.Unlock()
+	volumeFunc = fn
+}
+
+// currentVolume returns the playback volume in percent (0-100)
+func currentVolume() int {
+	volumeMu.RLock()
+	fn := volumeFunc
+	volumeMu.RUnlock()
+	if fn == nil {
+		return 100
+	}
+	volume := fn()
+	if volume < 0 {
+		return 0
+	}
+	if volume > 100 {
+		return 100
+	}
+	return volume
+}
+
 // PlayAudio memutar file audio (blocking untuk satu instance)
 func PlayAudio(filePath string, duration time.Duration) error {
 	return globalPlayer.Play(filePath, duration)
@@ -25,6 +61,14 @@ func PlayAudio(filePath string, duration time.Duration) error {
 
 // Play memutar audio file dengan durasi tertentu
 func (ap *AudioPlayer) Play(filePath string, duration time.Duration) error {
+	filePath = ResolveDataPath(filePath)
+
+	volume := currentVolume()
+	if volume == 0 {
+		log.Printf("🔇 Audio muted, skipping: %s", filePath)
+		return ErrAudioMuted
+	}
+
 	ap.mu.Lock()
 
 	// Stop audio yang sedang playing
@@ -38,11 +82,11 @@ func (ap *AudioPlayer) Play(filePath string, duration time.Duration) error {
 
 	switch runtime.GOOS {
 	case "windows":
-		cmd = ap.playWindows(filePath)
+		cmd = ap.playWindows(filePath, volume)
 	case "linux":
-		cmd = ap.playLinux(filePath)
+		cmd = ap.playLinux(filePath, volume)
 	case "darwin":
-		cmd = ap.playMacOS(filePath)
+		cmd = ap.playMacOS(filePath, volume)
 	default:
 		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 	}
@@ -60,7 +104,11 @@ func (ap *AudioPlayer) Play(filePath string, duration time.Duration) error {
 		return fmt.Errorf("failed to start audio playback: %v", err)
 	}
 
-	log.Printf("🔊 Audio playback started: %s", filePath)
+	if volume < 100 {
+		log.Printf("🔉 Audio playback started at %d%% volume: %s", volume, filePath)
+	} else {
+		log.Printf("🔊 Audio playback started: %s", filePath)
+	}
 
 	// Stop audio after duration
 	if duration > 0 {
@@ -149,49 +197,54 @@ func (ap *AudioPlayer) IsPlaying() bool {
 //}
 
 // ===== LINUX =====
-func (ap *AudioPlayer) playLinux(filePath string) *exec.Cmd {
+// volume dalam persen (1-100)
+func (ap *AudioPlayer) playLinux(filePath string, volume int) *exec.Cmd {
 	// Coba beberapa audio player yang umum di Linux
 	// Priority: paplay > aplay > ffplay > mpg123
 
-	// Cek paplay (PulseAudio)
+	// Cek paplay (PulseAudio), volume 65536 = 100%
 	if _, err := exec.LookPath("paplay"); err == nil {
-		return exec.Command("paplay", filePath)
+		return exec.Command("paplay", fmt.Sprintf("--volume=%d", 65536*volume/100), filePath)
 	}
 
-	// Cek aplay (ALSA) - untuk .wav files
-	if _, err := exec.LookPath("aplay"); err == nil {
+	// Cek aplay (ALSA) - untuk .wav files, tidak punya opsi volume
+	// jadi hanya dipakai untuk volume penuh
+	if _, err := exec.LookPath("aplay"); err == nil && volume == 100 {
 		return exec.Command("aplay", filePath)
 	}
 
 	// Cek ffplay (FFmpeg)
 	if _, err := exec.LookPath("ffplay"); err == nil {
-		return exec.Command("ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet", filePath)
+		return exec.Command("ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet", "-volume", fmt.Sprint(volume), filePath)
 	}
 
-	// Cek mpg123
+	// Cek mpg123, scale 32768 = 100%
 	if _, err := exec.LookPath("mpg123"); err == nil {
-		return exec.Command("mpg123", "-q", filePath)
+		return exec.Command("mpg123", "-q", "-f", fmt.Sprint(32768*volume/100), filePath)
 	}
 
 	// Cek mplayer
 	if _, err := exec.LookPath("mplayer"); err == nil {
-		return exec.Command("mplayer", "-really-quiet", filePath)
+		return exec.Command("mplayer", "-really-quiet", "-softvol", "-volume", fmt.Sprint(volume), filePath)
 	}
 
-	// Fallback: cvlc (VLC command line)
-	return exec.Command("cvlc", "--play-and-exit", "--quiet", filePath)
+	// Fallback: cvlc (VLC command line), gain 1.0 = 100%
+	return exec.Command("cvlc", "--play-and-exit", "--quiet", fmt.Sprintf("--gain=%.2f", float64(volume)/100), filePath)
 }
 
 // ===== macOS =====
-func (ap *AudioPlayer) playMacOS(filePath string) *exec.Cmd {
-	// Menggunakan afplay (built-in di macOS)
-	return exec.Command("afplay", filePath)
+func (ap *AudioPlayer) playMacOS(filePath string, volume int) *exec.Cmd {
+	// Menggunakan afplay (built-in di macOS), volume 1.0 = 100%
+	return exec.Command("afplay", "-v", fmt.Sprintf("%.2f", float64(volume)/100), filePath)
 }
 
 // PlayAudioAsync memutar audio secara asynchronous tanpa blocking
 func PlayAudioAsync(filePath string, duration time.Duration) {
 	go func() {
 		err := PlayAudio(filePath, duration)
+		if errors.Is(err, ErrAudioMuted) {
+			return
+		}
 		if err != nil {
 			log.Printf("❌ Failed to play audio: %v", err)
 		} else {
@@ -48,13 +48,14 @@ $player.Dispose()
 	return exec.Command("powershell", "-ExecutionPolicy", "Bypass", "-NoProfile", "-Command", script)
 }
 
-// playWindowsMethod3 menggunakan presentationCore untuk MP3
-func playWindowsMethod3(filePath string) *exec.Cmd {
+// playWindowsMethod3 menggunakan presentationCore untuk MP3, volume dalam persen
+func playWindowsMethod3(filePath string, volume int) *exec.Cmd {
 	absPath, _ := filepath.Abs(filePath)
 	script := fmt.Sprintf(`
 Add-Type -AssemblyName presentationCore
 $mediaPlayer = New-Object System.Windows.Media.MediaPlayer
 $mediaPlayer.Open([System.Uri]::new('%s'))
+$mediaPlayer.Volume = %.2f
 $mediaPlayer.Play()
 
 # Wait for the duration
@@ -62,7 +63,7 @@ Start-Sleep -Seconds 15
 
 $mediaPlayer.Stop()
 $mediaPlayer.Close()
-`, absPath)
+`, absPath, float64(volume)/100)
 
 	return exec.Command("powershell", "-ExecutionPolicy", "Bypass", "-NoProfile", "-Command", script)
 }
@@ -93,7 +94,7 @@ func playWindowsMethod5(filePath string) *exec.Cmd {
 }
 
 // tryPlayWindows mencoba berbagai metode sampai ada yang berhasil
-func (ap *AudioPlayer) playWindows(filePath string) *exec.Cmd {
+func (ap *AudioPlayer) playWindows(filePath string, volume int) *exec.Cmd {
 	log.Println("🎵 Trying Windows audio playback methods...")
 
 	// Method 1: Windows Media Player COM (Recommended)
@@ -104,7 +105,7 @@ func (ap *AudioPlayer) playWindows(filePath string) *exec.Cmd {
 
 	// Method 3: PresentationCore MediaPlayer
 	log.Println("   Method 3: PresentationCore MediaPlayer")
-	cmd := playWindowsMethod3(filePath)
+	cmd := playWindowsMethod3(filePath, volume)
 
 	// Method 4: VLC
 	// cmd := playWindowsMethod4(filePath)
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif" // decoder untuk cover .gif
+	_ "image/jpeg"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // cover dari situs anime sering .webp
+)
+
+const (
+	// CoverCacheDir adalah folder cache ikon cover di data directory
+	CoverCacheDir = "cache/covers"
+
+	// CoverIconSize adalah ukuran maksimum ikon notifikasi
+	CoverIconSize = 256
+
+	// CoverThumbSize adalah ukuran thumbnail di daftar anime
+	CoverThumbSize = 96
+)
+
+// coverSourceKey mengidentifikasi file cover asli di nama file cache
+func coverSourceKey(src string) string {
+	sum := sha1.Sum([]byte(src))
+	return hex.EncodeToString(sum[:8])
+}
+
+// CoverIcon mengubah cover anime menjadi PNG yang muat di kotak size x size dan
+// menyimpannya di cache. Cache diperbarui otomatis jika file cover berubah.
+func CoverIcon(imagePath string, size int) (string, error) {
+	if imagePath == "" {
+		return "", fmt.Errorf("anime has no cover image")
+	}
+	src := ResolveDataPath(imagePath)
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+
+	// Nama cache: <path cover>-<versi file cover>-<ukuran>.png,
+	// versi diambil dari ukuran dan waktu ubah file cover
+	sourceKey := coverSourceKey(src)
+	version := sha1.Sum([]byte(fmt.Sprintf("%d|%d", info.Size(), info.ModTime().UnixNano())))
+	versionKey := hex.EncodeToString(version[:8])
+	cached := DataPath(CoverCacheDir, fmt.Sprintf("%s-%s-%d.png", sourceKey, versionKey, size))
+	if _, err := os.Stat(cached); err == nil {
+		return cached, nil
+	}
+
+	file, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode cover %s: %v", imagePath, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+		return "", err
+	}
+	// Nama sementara unik, karena daftar anime dan scheduler bisa membuat cache yang sama bersamaan
+	out, err := os.CreateTemp(filepath.Dir(cached), sourceKey+"-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	tmpPath := out.Name()
+	if err := png.Encode(out, scaleToFit(img, size)); err != nil {
+		out.Close()
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, cached); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	// Versi lama dari cover yang sama tidak akan dipakai lagi
+	pruneCoverCache(src, sourceKey+"-"+versionKey+"-")
+	return cached, nil
+}
+
+// pruneCoverCache menghapus cache milik cover src, kecuali yang namanya diawali keep
+// (kosong berarti hapus semua)
+func pruneCoverCache(src, keep string) {
+	matches, err := filepath.Glob(DataPath(CoverCacheDir, coverSourceKey(src)+"-*.png"))
+	if err != nil {
+		return
+	}
+	for _, match := range matches {
+		if keep != "" && strings.HasPrefix(filepath.Base(match), keep) {
+			continue
+		}
+		if err := os.Remove(match); err != nil && !os.IsNotExist(err) {
+			log.Printf("⚠️ Failed to remove cached cover %s: %v", match, err)
+		}
+	}
+}
+
+// RemoveCoverCache menghapus semua ukuran cache milik cover imagePath,
+// dipanggil saat file cover dihapus
+func RemoveCoverCache(imagePath string) {
+	if imagePath == "" {
+		return
+	}
+	pruneCoverCache(ResolveDataPath(imagePath), "")
+}
+
+// scaleToFit memperkecil img supaya muat di size x size, rasio aspek tetap
+func scaleToFit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+
+	if w >= h {
+		h = h * size / w
+		w = size
+	} else {
+		w = w * size / h
+		h = size
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
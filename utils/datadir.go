@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+const (
+	// DataDirEnv adalah environment variable untuk override lokasi data
+	DataDirEnv = "ANIME_REMINDER_DATA_DIR"
+
+	// DataDirName adalah nama folder aplikasi di bawah XDG_DATA_HOME
+	DataDirName = "anime-reminder"
+
+	// DatabaseFileName adalah nama file SQLite di dalam data directory
+	DatabaseFileName = "anime_reminder.db"
+
+	// UploadsDirName adalah nama folder upload di dalam data directory
+	UploadsDirName = "uploads"
+)
+
+var (
+	dataDir   string
+	dataDirMu sync.RWMutex
+)
+
+// ResolveDataDir menentukan data root dengan urutan prioritas:
+// flag --data-dir, lalu ANIME_REMINDER_DATA_DIR, lalu $XDG_DATA_HOME/anime-reminder
+func ResolveDataDir(flagValue string) (string, error) {
+	if flagValue != "" {
+		return filepath.Abs(flagValue)
+	}
+
+	if envValue := os.Getenv(DataDirEnv); envValue != "" {
+		return filepath.Abs(envValue)
+	}
+
+	base, err := xdgDataHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, DataDirName), nil
+}
+
+// xdgDataHome mengembalikan $XDG_DATA_HOME atau default per OS
+func xdgDataHome() (string, error) {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" && filepath.IsAbs(xdg) {
+		return xdg, nil
+	}
+
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		// APPDATA di Windows, ~/Library/Application Support di macOS
+		return os.UserConfigDir()
+	default:
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(homeDir, ".local", "share"), nil
+	}
+}
+
+// SetDataDir mengatur data root yang dipakai seluruh aplikasi dan membuat foldernya
+func SetDataDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory %s: %v", dir, err)
+	}
+
+	dataDirMu.Lock()
+	dataDir = dir
+	dataDirMu.Unlock()
+	return nil
+}
+
+// DataDir returns the resolved data root (empty means current directory)
+func DataDir() string {
+	dataDirMu.RLock()
+	defer dataDirMu.RUnlock()
+	return dataDir
+}
+
+// DataPath menggabungkan elemen path relatif terhadap data root
+func DataPath(elem ...string) string {
+	return filepath.Join(append([]string{DataDir()}, elem...)...)
+}
+
+// ResolveDataPath mengubah path yang disimpan di database (relatif terhadap data root)
+// menjadi path yang bisa dibuka. Path absolut dikembalikan apa adanya.
+func ResolveDataPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return DataPath(path)
+}
+
+// databaseSidecars adalah file pendamping SQLite yang berisi data yang mungkin
+// belum masuk ke file utama, harus ikut dipindahkan bersama database
+var databaseSidecars = []string{"-wal", "-shm", "-journal"}
+
+// MigrateLegacyData memindahkan database dan folder uploads/ dari current working
+// directory (lokasi lama) ke data root. Hanya dijalankan sekali: jika data root
+// sudah punya database, tidak ada yang dipindahkan.
+//
+// Database dipindahkan paling akhir, jadi jika langkah sebelumnya gagal,
+// run berikutnya mengulang semuanya (file yang sudah pindah dilewati).
+func MigrateLegacyData(root string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	cwdAbs, _ := filepath.Abs(cwd)
+	rootAbs, _ := filepath.Abs(root)
+	if cwdAbs == rootAbs {
+		return nil
+	}
+
+	legacyDB := filepath.Join(cwdAbs, DatabaseFileName)
+	if _, err := os.Stat(legacyDB); os.IsNotExist(err) {
+		return nil
+	}
+
+	newDB := filepath.Join(rootAbs, DatabaseFileName)
+	if _, err := os.Stat(newDB); err == nil {
+		log.Printf("⚠️ Legacy database found at %s but %s already exists (skipping migration)", legacyDB, newDB)
+		return nil
+	}
+
+	if err := os.MkdirAll(rootAbs, 0755); err != nil {
+		return err
+	}
+
+	legacyUploads := filepath.Join(cwdAbs, UploadsDirName)
+	if info, err := os.Stat(legacyUploads); err == nil && info.IsDir() {
+		if err := moveTree(legacyUploads, filepath.Join(rootAbs, UploadsDirName)); err != nil {
+			return fmt.Errorf("failed to move uploads: %v", err)
+		}
+		log.Printf("📦 Uploads moved: %s -> %s", legacyUploads, filepath.Join(rootAbs, UploadsDirName))
+	}
+
+	for _, suffix := range databaseSidecars {
+		legacySidecar := legacyDB + suffix
+		if _, err := os.Stat(legacySidecar); os.IsNotExist(err) {
+			continue
+		}
+		if err := moveFile(legacySidecar, newDB+suffix); err != nil {
+			return fmt.Errorf("failed to move database %s file: %v", suffix, err)
+		}
+	}
+
+	if err := moveFile(legacyDB, newDB); err != nil {
+		return fmt.Errorf("failed to move database: %v", err)
+	}
+	log.Printf("📦 Database moved: %s -> %s", legacyDB, newDB)
+
+	return nil
+}
+
+// moveTree memindahkan semua file di src ke dst, file yang sudah ada di dst tidak ditimpa
+func moveTree(src, dst string) error {
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		if _, err := os.Stat(target); err == nil {
+			log.Printf("⚠️ %s already exists (skipping)", target)
+			return nil
+		}
+		return moveFile(path, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Hapus folder lama jika sudah kosong
+	cleanupEmptyDirs(src)
+	return nil
+}
+
+// cleanupEmptyDirs menghapus folder kosong secara rekursif (bottom-up)
+func cleanupEmptyDirs(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			cleanupEmptyDirs(filepath.Join(dir, entry.Name()))
+		}
+	}
+	// os.Remove gagal jika folder tidak kosong, itu yang kita mau
+	os.Remove(dir)
+}
+
+// moveFile memindahkan file, dengan fallback copy+delete jika beda filesystem
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		in.Close()
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	in.Close()
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst)
+		return err
+	}
+
+	return os.Remove(src)
+}
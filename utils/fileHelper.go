@@ -12,6 +12,9 @@ func DeleteOldFile(oldFilePath string) error {
 	if oldFilePath == "" {
 		return nil // Tidak ada file lama
 	}
+	oldFilePath = ResolveDataPath(oldFilePath)
+	// Cache cover dari file ini tidak berguna lagi
+	RemoveCoverCache(oldFilePath)
 
 	// Check if file exists
 	if _, err := os.Stat(oldFilePath); os.IsNotExist(err) {
@@ -56,8 +59,8 @@ func DeleteOldFileIfDifferent(oldFilePath, newFilePath string) error {
 	}
 
 	// Convert to absolute path untuk perbandingan
-	oldAbs, _ := filepath.Abs(oldFilePath)
-	newAbs, _ := filepath.Abs(newFilePath)
+	oldAbs, _ := filepath.Abs(ResolveDataPath(oldFilePath))
+	newAbs, _ := filepath.Abs(ResolveDataPath(newFilePath))
 
 	if oldAbs == newAbs {
 		return nil
@@ -71,7 +74,7 @@ func CleanupOrphanedFiles(directory string, usedFiles []string) error {
 	// Buat map untuk cek file yang masih digunakan
 	usedMap := make(map[string]bool)
 	for _, file := range usedFiles {
-		absPath, _ := filepath.Abs(file)
+		absPath, _ := filepath.Abs(ResolveDataPath(file))
 		usedMap[absPath] = true
 	}
 